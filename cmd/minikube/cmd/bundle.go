@@ -0,0 +1,172 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/bundle"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/util"
+	"k8s.io/minikube/pkg/version"
+)
+
+var (
+	bundleKubernetesVersion string
+	bundleISOURL            string
+	bundleOutput            string
+)
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export or import the files needed to start a cluster offline.",
+	Long: `Export or import a bundle of the ISO, Kubernetes binaries and images needed to start a cluster.
+A bundle exported on a machine with network access can be imported on a disconnected machine, where "minikube start" then works offline.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// exportBundleCmd represents the bundle export command
+var exportBundleCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Downloads and exports the files needed to start a cluster offline.",
+	Long:  "Downloads the ISO, Kubernetes binaries and images for a Kubernetes version into the cache, and exports them as a single archive.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			exit.Usage("usage: minikube bundle export [--kubernetes-version=VERSION] [--output=FILE]")
+		}
+		k8sVersion := bundleKubernetesVersion
+		output := bundleOutput
+		if output == "" {
+			output = fmt.Sprintf("minikube-bundle-%s.tar.gz", k8sVersion)
+		}
+		bs := viper.GetString(cmdcfg.Bootstrapper)
+
+		downloader := util.DefaultDownloader{}
+		if err := downloader.CacheMinikubeISOFromURL(bundleISOURL); err != nil {
+			exit.WithError("Failed to cache ISO", err)
+		}
+		if err := machine.CacheBinariesForBootstrapper(k8sVersion, bs); err != nil {
+			exit.WithError("Failed to cache binaries", err)
+		}
+		console.OutStyle("pulling", "Caching images for Kubernetes %s ...", k8sVersion)
+		if err := machine.CacheImagesForBootstrapper("", k8sVersion, bs); err != nil {
+			exit.WithError("Failed to cache images", err)
+		}
+
+		files, err := bundleFiles(downloader.GetISOCacheFilepath(bundleISOURL), k8sVersion, bs)
+		if err != nil {
+			exit.WithError("Failed to list files", err)
+		}
+		m := &bundle.Manifest{MinikubeVersion: version.GetVersion(), KubernetesVersion: k8sVersion}
+		if err := writeBundle(output, m, files); err != nil {
+			exit.WithError("Failed to export bundle", err)
+		}
+		console.OutStyle("success", "Exported %d files to %s", len(m.Files), output)
+	},
+}
+
+// importBundleCmd represents the bundle import command
+var importBundleCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Verifies and imports a bundle into the cache.",
+	Long:  "Verifies the files of a bundle created by \"minikube bundle export\", and unpacks them into the cache of the minikube home directory.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Usage("usage: minikube bundle import FILE")
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			exit.WithCode(exit.NoInput, "Unable to open bundle: %v", err)
+		}
+		defer f.Close()
+		m, err := bundle.Read(f, constants.GetMinipath())
+		if err != nil {
+			exit.WithError("Failed to import bundle", err)
+		}
+		console.OutStyle("success", "Imported %d files for Kubernetes %s", len(m.Files), m.KubernetesVersion)
+		if m.MinikubeVersion != version.GetVersion() {
+			console.OutStyle("warning", "The bundle was exported by minikube %s, and may not match the defaults of minikube %s", m.MinikubeVersion, version.GetVersion())
+		}
+		console.OutStyle("tip", "To start a cluster offline: minikube start --kubernetes-version=%s", m.KubernetesVersion)
+	},
+}
+
+// bundleFiles returns the cached files needed to start a cluster, relative to the minikube home directory
+func bundleFiles(iso string, k8sVersion string, bs string) ([]string, error) {
+	paths := []string{iso}
+	for _, bin := range bootstrapper.GetCachedBinaryList(bs) {
+		paths = append(paths, constants.MakeMiniPath("cache", k8sVersion, bin))
+	}
+	for _, image := range bootstrapper.GetCachedImageList("", k8sVersion, bs) {
+		p := machine.CachedImagePath(image)
+		paths = append(paths, p)
+		// Include the recorded digest, so that the image isn't verified from scratch on load
+		if _, err := os.Stat(p + ".sha256"); err == nil {
+			paths = append(paths, p+".sha256")
+		}
+	}
+
+	var files []string
+	for _, p := range paths {
+		rel, err := filepath.Rel(constants.GetMinipath(), p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rel)
+	}
+	return files, nil
+}
+
+// writeBundle writes a bundle to a file, which is only created once complete
+func writeBundle(output string, m *bundle.Manifest, files []string) error {
+	tf, err := ioutil.TempFile(filepath.Dir(output), filepath.Base(output)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+	if err := bundle.Write(tf, constants.GetMinipath(), m, files); err != nil {
+		tf.Close()
+		return err
+	}
+	if err := tf.Close(); err != nil {
+		return err
+	}
+	return errors.Wrap(os.Rename(tf.Name(), output), "rename")
+}
+
+func init() {
+	exportBundleCmd.Flags().StringVar(&bundleKubernetesVersion, "kubernetes-version", constants.DefaultKubernetesVersion, "The Kubernetes version to export the binaries and images of")
+	exportBundleCmd.Flags().StringVar(&bundleISOURL, "iso-url", constants.DefaultISOURL, "Location of the minikube iso to export")
+	exportBundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Path of the bundle to write (default \"minikube-bundle-<kubernetes-version>.tar.gz\")")
+	bundleCmd.AddCommand(exportBundleCmd)
+	bundleCmd.AddCommand(importBundleCmd)
+	RootCmd.AddCommand(bundleCmd)
+}
@@ -17,14 +17,30 @@ limitations under the License.
 package cmd
 
 import (
+	"os"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	cmdConfig "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/machine"
 )
 
+var (
+	cacheProfileOnly bool
+	// cacheAddons are the addons passed to --addon, whose images are cached
+	cacheAddons []string
+)
+
 // cacheCmd represents the cache command
 var cacheCmd = &cobra.Command{
 	Use:   "cache",
@@ -36,16 +52,39 @@ var cacheCmd = &cobra.Command{
 var addCacheCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add an image to local cache.",
-	Long:  "Add an image to local cache.",
+	Long:  "Add an image to local cache. By default, the image is loaded by every profile; use --profile-only to load it only in the current profile. The images of addons, with their overrides, are cached for the current profile with --addon.",
 	Run: func(cmd *cobra.Command, args []string) {
-		// Cache and load images into docker daemon
-		if err := machine.CacheAndLoadImages(args); err != nil {
-			exit.WithError("Failed to cache and load images", err)
+		if len(cacheAddons) > 0 {
+			// the images of addons depend on the overrides of the profile, so they are cached for it only
+			cc := loadCacheProfileConfig()
+			images := args
+			for _, name := range cacheAddons {
+				addon, ok := assets.Addons[name]
+				if !ok {
+					exit.WithCode(exit.Data, "addon '%s' is not a valid addon. To see the list of available addons run: minikube addons list", name)
+				}
+				addonImages, err := addon.ImagesFor(cc.KubernetesConfig)
+				if err != nil {
+					exit.WithError("Failed to get the images of the addon", err)
+				}
+				images = append(images, addonImages...)
+			}
+			cacheProfileImages(cc, images)
+			return
 		}
-		// Add images to config file
-		if err := cmdConfig.AddToConfigMap(constants.Cache, args); err != nil {
-			exit.WithError("Failed to update config", err)
+		if !cacheProfileOnly {
+			// Cache and load images into docker daemon
+			if err := machine.CacheAndLoadImages(args); err != nil {
+				exit.WithError("Failed to cache and load images", err)
+			}
+			// Add images to config file
+			if err := cmdConfig.AddToConfigMap(constants.Cache, args); err != nil {
+				exit.WithError("Failed to update config", err)
+			}
+			return
 		}
+
+		cacheProfileImages(loadCacheProfileConfig(), args)
 	},
 }
 
@@ -53,32 +92,150 @@ var addCacheCmd = &cobra.Command{
 var deleteCacheCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete an image from the local cache.",
-	Long:  "Delete an image from the local cache.",
+	Long:  "Delete an image from the local cache. The cached file is only removed once no profile references the image.",
 	Run: func(cmd *cobra.Command, args []string) {
-		// Delete images from config file
-		if err := cmdConfig.DeleteFromConfigMap(constants.Cache, args); err != nil {
-			exit.WithError("Failed to delete images from config", err)
+		if cacheProfileOnly {
+			cc := loadCacheProfileConfig()
+			if cc.RemoveCachedImages(args) {
+				if err := config.SaveProfile(config.GetMachineName(), cc); err != nil {
+					exit.WithError("Failed to update config", err)
+				}
+			}
+		} else {
+			// Delete images from config file
+			if err := cmdConfig.DeleteFromConfigMap(constants.Cache, args); err != nil {
+				exit.WithError("Failed to delete images from config", err)
+			}
+		}
+
+		refs, err := cachedImageReferences()
+		if err != nil {
+			exit.WithError("Failed to get cached images", err)
+		}
+		var unused []string
+		for _, image := range args {
+			if len(refs[image]) > 0 {
+				console.OutStyle("notice", "%s is still cached for: %s", image, formatReferences(refs[image]))
+				continue
+			}
+			if _, err := os.Stat(machine.CachedImagePath(image)); err == nil {
+				unused = append(unused, image)
+			}
 		}
 		// Delete images from cache/images directory
-		if err := machine.DeleteFromImageCacheDir(args); err != nil {
+		if err := machine.DeleteFromImageCacheDir(unused); err != nil {
 			exit.WithError("Failed to delete images", err)
 		}
 	},
 }
 
+// reloadCacheCmd represents the cache reload command
+var reloadCacheCmd = &cobra.Command{
+	Use:   "reload",
+	Short: "Reload the cached images into the running cluster.",
+	Long:  "Caches any missing image of the current profile, and loads every cached image of the profile into the nodes of the running cluster.",
+	Run: func(cmd *cobra.Command, args []string) {
+		images, err := imagesInConfigFile()
+		if err != nil {
+			exit.WithError("Failed to get cached images", err)
+		}
+		if len(images) == 0 {
+			console.OutStyle("meh", "There are no cached images for %q", config.GetMachineName())
+			return
+		}
+		if err := machine.CacheImages(images, constants.ImageCacheDir); err != nil {
+			exit.WithError("Failed to cache images", err)
+		}
+
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+		err = forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+			console.OutStyle("option", "Loading %d cached images into %q", len(images), name)
+			return machine.LoadImages(r, images, constants.ImageCacheDir)
+		})
+		if err != nil {
+			exit.WithError("Failed to reload cached images", err)
+		}
+	},
+}
+
+// cacheProfileImages caches images for the profile only, loading them if it is running
+func cacheProfileImages(cc *config.Config, images []string) {
+	if err := machine.CacheImages(images, constants.ImageCacheDir); err != nil {
+		exit.WithError("Failed to cache images", err)
+	}
+	cc.AddCachedImages(images)
+	if err := config.SaveProfile(config.GetMachineName(), cc); err != nil {
+		exit.WithError("Failed to update config", err)
+	}
+	if err := loadImagesIfRunning(images); err != nil {
+		exit.WithError("Failed to load images", err)
+	}
+}
+
+// loadCacheProfileConfig loads the config of the current profile, which must exist
+func loadCacheProfileConfig() *config.Config {
+	cc, err := config.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			exit.WithCode(exit.NoInput, "%q profile does not exist", config.GetMachineName())
+		}
+		exit.WithError("Error loading profile config", err)
+	}
+	return cc
+}
+
+// loadImagesIfRunning loads cached images into the nodes of the cluster, if it is running
+func loadImagesIfRunning(images []string) error {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	s, err := cluster.GetHostStatus(api, config.GetMachineName())
+	if err != nil {
+		return errors.Wrap(err, "host status")
+	}
+	if s != state.Running.String() {
+		glog.Infof("%s is %s, images will be loaded on start", config.GetMachineName(), s)
+		return nil
+	}
+	return forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+		return machine.LoadImages(r, images, constants.ImageCacheDir)
+	})
+}
+
+// imagesInConfigFile returns the images of the global cache, along with the ones of the current profile
 func imagesInConfigFile() ([]string, error) {
 	configFile, err := config.ReadConfig()
 	if err != nil {
 		return nil, err
 	}
+	var images []string
+	seen := map[string]bool{}
 	if values, ok := configFile[constants.Cache]; ok {
-		var images []string
 		for key := range values.(map[string]interface{}) {
+			seen[key] = true
 			images = append(images, key)
 		}
-		return images, nil
 	}
-	return []string{}, nil
+	cc, err := config.Load()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if cc != nil {
+		for _, image := range cc.CachedImages {
+			if !seen[image] {
+				seen[image] = true
+				images = append(images, image)
+			}
+		}
+	}
+	return images, nil
 }
 
 // CacheImagesInConfigFile caches the images currently in the config file (minikube start)
@@ -106,7 +263,11 @@ func LoadCachedImagesInConfigFile() error {
 }
 
 func init() {
+	addCacheCmd.Flags().BoolVar(&cacheProfileOnly, "profile-only", false, "Cache the images for the current profile only, rather than for every profile")
+	addCacheCmd.Flags().StringArrayVar(&cacheAddons, "addon", nil, "Cache the images of an addon for the current profile, with their overrides (can be repeated)")
+	deleteCacheCmd.Flags().BoolVar(&cacheProfileOnly, "profile-only", false, "Delete the images from the cache of the current profile only")
 	cacheCmd.AddCommand(addCacheCmd)
 	cacheCmd.AddCommand(deleteCacheCmd)
+	cacheCmd.AddCommand(reloadCacheCmd)
 	RootCmd.AddCommand(cacheCmd)
 }
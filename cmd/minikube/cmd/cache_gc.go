@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+var cacheGCDryRun bool
+
+// gcCacheCmd represents the cache gc command
+var gcCacheCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove unused images from the local cache.",
+	Long: `Remove the images from the local cache which are neither needed by the Kubernetes version of a profile
+or the default Kubernetes version, nor by an enabled addon, nor referenced by the global cache or the cache of a profile.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		keep, err := referencedCacheImages()
+		if err != nil {
+			exit.WithError("Failed to get referenced images", err)
+		}
+		removed, size, err := machine.PruneImageCache(constants.ImageCacheDir, keep, cacheGCDryRun)
+		if err != nil {
+			exit.WithError("Failed to remove unused images", err)
+		}
+		if len(removed) == 0 {
+			console.OutStyle("check", "There are no unused images in the cache")
+			return
+		}
+		for _, f := range removed {
+			console.OutStyle("option", "%s", f)
+		}
+		if cacheGCDryRun {
+			console.OutStyle("notice", "%d unused files would be removed, freeing %s", len(removed), units.HumanSize(float64(size)))
+			return
+		}
+		console.OutStyle("crushed", "Removed %d unused files, freeing %s", len(removed), units.HumanSize(float64(size)))
+	},
+}
+
+// referencedCacheImages returns the images which cache gc must keep
+func referencedCacheImages() ([]string, error) {
+	refs, err := cachedImageReferences()
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	for image := range refs {
+		images = append(images, image)
+	}
+
+	bs := viper.GetString(cmdcfg.Bootstrapper)
+	images = append(images, bootstrapper.GetCachedImageList("", constants.DefaultKubernetesVersion, bs)...)
+	profiles, _, err := config.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		k8s := p.Config.KubernetesConfig
+		addonImages, err := enabledAddonImages(k8s)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, addonImages...)
+		if k8s.KubernetesVersion == "" {
+			continue
+		}
+		images = append(images, bootstrapper.GetCachedImageList(k8s.ImageRepository, k8s.KubernetesVersion, bs)...)
+	}
+	return images, nil
+}
+
+// enabledAddonImages returns the images which enabling the addons cached, with the overrides of a profile
+func enabledAddonImages(k8s config.KubernetesConfig) ([]string, error) {
+	var images []string
+	for name, addon := range assets.Addons {
+		if len(addon.Images) == 0 {
+			continue
+		}
+		enabled, err := addon.IsEnabled()
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking if addon %s is enabled", name)
+		}
+		if !enabled {
+			continue
+		}
+		addonImages, err := addon.ImagesFor(k8s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "images of addon %s", name)
+		}
+		images = append(images, addonImages...)
+	}
+	return images, nil
+}
+
+func init() {
+	gcCacheCmd.Flags().BoolVar(&cacheGCDryRun, "dry-run", false, "Only show the files which would be removed")
+	cacheCmd.AddCommand(gcCacheCmd)
+}
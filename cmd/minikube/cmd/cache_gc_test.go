@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func TestEnabledAddonImages(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "minikube")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer os.Setenv(constants.MinikubeHome, os.Getenv(constants.MinikubeHome))
+	os.Setenv(constants.MinikubeHome, tempDir)
+
+	enabled := assets.NewAddon(nil, true, "gc-enabled")
+	enabled.Images = []string{"example.com/enabled:1.0"}
+	disabled := assets.NewAddon(nil, false, "gc-disabled")
+	disabled.Images = []string{"example.com/disabled:1.0"}
+	assets.Addons["gc-enabled"] = enabled
+	assets.Addons["gc-disabled"] = disabled
+	defer delete(assets.Addons, "gc-enabled")
+	defer delete(assets.Addons, "gc-disabled")
+
+	k8s := config.KubernetesConfig{
+		AddonImages: map[string]config.AddonImages{
+			"gc-enabled": {Registry: "mirror.example.com"},
+		},
+	}
+	images, err := enabledAddonImages(k8s)
+	if err != nil {
+		t.Fatalf("enabledAddonImages: %v", err)
+	}
+	expected := []string{"mirror.example.com/enabled:1.0"}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("enabledAddonImages = %v, expected %v", images, expected)
+	}
+}
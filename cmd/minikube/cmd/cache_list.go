@@ -18,55 +18,133 @@ package cmd
 
 import (
 	"os"
+	"sort"
+	"strings"
 	"text/template"
 
+	units "github.com/docker/go-units"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	cmdConfig "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
 )
 
-var cacheListFormat string
+// allProfiles is the reference shown for images in the global cache
+const allProfiles = "*"
+
+var (
+	cacheListFormat string
+	cacheListOutput string
+)
 
 // CacheListTemplate represents the cache list template
 type CacheListTemplate struct {
 	CacheImage string
+	// Profiles is a comma separated list of the profiles which cache the image, where * stands for every profile
+	Profiles string
+	// Size is the human readable size of the cached image, or empty if it has not been cached yet
+	Size string
 }
 
 // listCacheCmd represents the cache list command
 var listCacheCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all available images from the local cache.",
-	Long:  "List all available images from the local cache.",
+	Long:  "List all available images from the local cache, along with the profiles which reference them and their size on disk.",
 	Run: func(cmd *cobra.Command, args []string) {
-		images, err := cmdConfig.ListConfigMap(constants.Cache)
+		refs, err := cachedImageReferences()
 		if err != nil {
 			exit.WithError("Failed to get image map", err)
 		}
-		if err := cacheList(images); err != nil {
-			exit.WithError("Failed to list cached images", err)
+		var images []CacheListTemplate
+		for image, profiles := range refs {
+			t := CacheListTemplate{CacheImage: image, Profiles: formatReferences(profiles)}
+			if fi, err := os.Stat(machine.CachedImagePath(image)); err == nil {
+				t.Size = units.HumanSize(float64(fi.Size()))
+			}
+			images = append(images, t)
+		}
+		sort.Slice(images, func(i, j int) bool { return images[i].CacheImage < images[j].CacheImage })
+
+		switch cacheListOutput {
+		case "table":
+			cacheTable(images)
+		case "":
+			if err := cacheList(images); err != nil {
+				exit.WithError("Failed to list cached images", err)
+			}
+		default:
+			exit.WithCode(exit.BadUsage, "Invalid output format: %s. Valid values: 'table'", cacheListOutput)
 		}
 	},
 }
 
+// cachedImageReferences returns the images of the global cache and of every profile, along with the profiles referencing them
+func cachedImageReferences() (map[string][]string, error) {
+	refs := map[string][]string{}
+	images, err := cmdConfig.ListConfigMap(constants.Cache)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		refs[image] = append(refs[image], allProfiles)
+	}
+	profiles, _, err := config.ListProfiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		for _, image := range p.Config.CachedImages {
+			refs[image] = append(refs[image], p.Name)
+		}
+	}
+	return refs, nil
+}
+
+// formatReferences returns the profiles referencing an image as a comma separated list
+func formatReferences(profiles []string) string {
+	sort.Strings(profiles)
+	return strings.Join(profiles, ", ")
+}
+
 func init() {
 	listCacheCmd.Flags().StringVar(&cacheListFormat, "format", constants.DefaultCacheListFormat,
 		`Go template format string for the cache list output.  The format for Go templates can be found here: https://golang.org/pkg/text/template/
 For the list of accessible variables for the template, see the struct values here: https://godoc.org/k8s.io/minikube/cmd/minikube/cmd#CacheListTemplate`)
+	listCacheCmd.Flags().StringVarP(&cacheListOutput, "output", "o", "", "Show the images as a table, instead of using the --format template. Valid values: 'table'")
 	cacheCmd.AddCommand(listCacheCmd)
 }
 
-func cacheList(images []string) error {
+func cacheList(images []CacheListTemplate) error {
 	for _, image := range images {
 		tmpl, err := template.New("list").Parse(cacheListFormat)
 		if err != nil {
 			exit.WithError("Unable to parse template", err)
 		}
-		listTmplt := CacheListTemplate{image}
-		err = tmpl.Execute(os.Stdout, listTmplt)
+		err = tmpl.Execute(os.Stdout, image)
 		if err != nil {
 			exit.WithError("Unable to process template", err)
 		}
 	}
 	return nil
 }
+
+func cacheTable(images []CacheListTemplate) {
+	var data [][]string
+	for _, i := range images {
+		size := i.Size
+		if size == "" {
+			size = "not cached"
+		}
+		data = append(data, []string{i.CacheImage, i.Profiles, size})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Image", "Profiles", "Size"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(data)
+	table.Render()
+}
@@ -17,17 +17,31 @@ limitations under the License.
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"text/template"
 
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/util"
 )
 
-var addonListFormat string
+var (
+	addonListFormat string
+	addonListOutput string
+)
 
 // AddonListTemplate represents the addon list template
 type AddonListTemplate struct {
@@ -35,6 +49,16 @@ type AddonListTemplate struct {
 	AddonStatus string
 }
 
+// AddonStatus represents an addon and the state of its pods, as printed by "addons list -o json"
+type AddonStatus struct {
+	Name    string
+	Enabled bool
+	// Pods is the readiness of the pods of an enabled addon, e.g. "1/1"
+	Pods   string   `json:",omitempty"`
+	Images []string `json:",omitempty"`
+	Error  string   `json:",omitempty"`
+}
+
 var addonsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Lists all available minikube addons as well as their current statuses (enabled/disabled)",
@@ -43,10 +67,24 @@ var addonsListCmd = &cobra.Command{
 		if len(args) != 0 {
 			exit.Usage("usage: minikube addons list")
 		}
-		err := addonList()
+		if addonListOutput == "" {
+			if err := addonList(); err != nil {
+				exit.WithError("addon list failed", err)
+			}
+			return
+		}
+		if addonListOutput != "table" && addonListOutput != "json" {
+			exit.Usage("invalid output format: %s. Valid values: 'table', 'json'", addonListOutput)
+		}
+		statuses, err := addonStatuses()
 		if err != nil {
 			exit.WithError("addon list failed", err)
 		}
+		if addonListOutput == "json" {
+			printAddonsJSON(statuses)
+		} else {
+			printAddonsTable(statuses)
+		}
 	},
 }
 
@@ -54,6 +92,7 @@ func init() {
 	AddonsCmd.Flags().StringVar(&addonListFormat, "format", constants.DefaultAddonListFormat,
 		`Go template format string for the addon list output.  The format for Go templates can be found here: https://golang.org/pkg/text/template/
 For the list of accessible variables for the template, see the struct values here: https://godoc.org/k8s.io/minikube/cmd/minikube/cmd/config#AddonListTemplate`)
+	addonsListCmd.Flags().StringVarP(&addonListOutput, "output", "o", "", "The output format, with the readiness and images of the pods of enabled addons. One of 'table', 'json'")
 	AddonsCmd.AddCommand(addonsListCmd)
 }
 
@@ -89,3 +128,98 @@ func addonList() error {
 	}
 	return nil
 }
+
+// addonStatuses returns the status of every addon, including its pods if it is enabled and minikube is running
+func addonStatuses() ([]AddonStatus, error) {
+	addonNames := make([]string, 0, len(assets.Addons))
+	for addonName := range assets.Addons {
+		addonNames = append(addonNames, addonName)
+	}
+	sort.Strings(addonNames)
+
+	var client corev1.PodsGetter
+	if isMinikubeRunning() {
+		c, err := util.GetClient()
+		if err != nil {
+			return nil, err
+		}
+		client = c.CoreV1()
+	}
+
+	statuses := []AddonStatus{}
+	for _, addonName := range addonNames {
+		addon := assets.Addons[addonName]
+		enabled, err := addon.IsEnabled()
+		if err != nil {
+			return nil, err
+		}
+		s := AddonStatus{Name: addonName, Enabled: enabled}
+		if enabled && client != nil && addon.Pods != nil {
+			ready, total, images, err := addonPods(client, addon.Pods)
+			if err != nil {
+				glog.Warningf("error getting the pods of %s: %v", addonName, err)
+				s.Error = err.Error()
+			} else {
+				s.Pods = fmt.Sprintf("%d/%d", ready, total)
+				s.Images = images
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// addonPods returns how many of the selected pods are ready, and the images of their containers
+func addonPods(client corev1.PodsGetter, s *assets.PodSelector) (ready int, total int, images []string, err error) {
+	selector, err := labels.Parse(s.Selector)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	pods, err := client.Pods(s.Namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	seen := map[string]bool{}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodReady && c.Status == v1.ConditionTrue {
+				ready++
+			}
+		}
+		for _, c := range pod.Spec.Containers {
+			if !seen[c.Image] {
+				seen[c.Image] = true
+				images = append(images, c.Image)
+			}
+		}
+	}
+	sort.Strings(images)
+	return ready, len(pods.Items), images, nil
+}
+
+func printAddonsTable(statuses []AddonStatus) {
+	var data [][]string
+	for _, s := range statuses {
+		pods := s.Pods
+		if s.Error != "" {
+			pods = "error"
+		}
+		data = append(data, []string{s.Name, stringFromStatus(s.Enabled), pods, strings.Join(s.Images, "\n")})
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Addon", "Status", "Pods Ready", "Images"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+	table.AppendBulk(data)
+	table.Render()
+}
+
+func printAddonsJSON(statuses []AddonStatus) {
+	out, err := json.Marshal(statuses)
+	if err != nil {
+		exit.WithError("Error encoding addons", err)
+	}
+	console.OutLn("%s", out)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+func addonPod(name string, ready bool, labels map[string]string, images ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system", Labels: labels},
+	}
+	for _, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name, Image: image})
+	}
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+	return pod
+}
+
+func TestAddonPods(t *testing.T) {
+	tracker := k8stesting.NewObjectTracker(scheme.Scheme, scheme.Codecs.UniversalDecoder())
+	for _, pod := range []*v1.Pod{
+		addonPod("backend", true, map[string]string{"app.kubernetes.io/name": "default-http-backend"}, "gcr.io/google_containers/defaultbackend:1.4"),
+		addonPod("controller", false, map[string]string{"app.kubernetes.io/name": "nginx-ingress-controller"}, "quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0"),
+		addonPod("controller2", true, map[string]string{"app.kubernetes.io/name": "nginx-ingress-controller"}, "quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0"),
+		addonPod("dashboard", true, map[string]string{"app": "kubernetes-dashboard"}, "k8s.gcr.io/kubernetes-dashboard-amd64:v1.10.1"),
+	} {
+		if err := tracker.Add(pod); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	f := &k8stesting.Fake{}
+	f.AddReactor("*", "*", k8stesting.ObjectReaction(tracker))
+	client := &fake.FakeCoreV1{Fake: f}
+
+	ready, total, images, err := addonPods(client, assets.Addons["ingress"].Pods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ready != 2 || total != 3 {
+		t.Errorf("expected 2/3 pods ready, got %d/%d", ready, total)
+	}
+	expected := []string{
+		"gcr.io/google_containers/defaultbackend:1.4",
+		"quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0",
+	}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("expected images %v, got %v", expected, images)
+	}
+
+	if _, _, _, err := addonPods(client, &assets.PodSelector{Namespace: "kube-system", Selector: "app in ("}); err == nil {
+		t.Errorf("expected an error for an invalid selector")
+	}
+}
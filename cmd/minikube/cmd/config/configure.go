@@ -17,14 +17,32 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/docker/machine/libmachine/state"
 	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	pkgConfig "k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/service"
 )
 
+var (
+	// addonValues are the KEY=VALUE pairs passed to --set
+	addonValues []string
+	// addonRegistry is the registry passed to --registry
+	addonRegistry string
+	// addonImages are the ORIGINAL=IMAGE pairs passed to --image
+	addonImages []string
+)
+
 var addonsConfigureCmd = &cobra.Command{
 	Use:   "configure ADDON_NAME",
 	Short: "Configures the addon w/ADDON_NAME within minikube (example: minikube addons configure registry-creds). For a list of available addons use: minikube addons list ",
@@ -35,6 +53,10 @@ var addonsConfigureCmd = &cobra.Command{
 		}
 
 		addon := args[0]
+		if len(addonValues) > 0 || addonRegistry != "" || len(addonImages) > 0 {
+			configureAddonSettings(addon)
+			return
+		}
 		// allows for additional prompting of information when enabling addons
 		switch addon {
 		case "registry-creds":
@@ -149,6 +171,13 @@ var addonsConfigureCmd = &cobra.Command{
 				console.Warning("ERROR creating `registry-creds-dpr` secret")
 			}
 		default:
+			if a, ok := assets.Addons[addon]; ok && len(a.Defaults) > 0 {
+				console.OutStyle("tip", "%s is configured with --set KEY=VALUE, where KEY is one of:", addon)
+				for _, k := range sortedKeys(a.Defaults) {
+					console.OutLn("  %s (default %q)", k, a.Defaults[k])
+				}
+				return
+			}
 			console.Failure("%s has no available configuration options", addon)
 			return
 		}
@@ -157,6 +186,98 @@ var addonsConfigureCmd = &cobra.Command{
 	},
 }
 
+// configureAddonSettings stores the values and image overrides of an addon in the profile,
+// and applies them if the addon is running
+func configureAddonSettings(name string) {
+	addon, ok := assets.Addons[name]
+	if !ok {
+		exit.WithCode(exit.Data, "addon '%s' is not a valid addon. To see the list of available addons run: minikube addons list", name)
+	}
+	values, err := parseAddonValues(addonValues)
+	if err != nil {
+		exit.Usage("%v", err)
+	}
+	if err := addon.ValidateValues(values); err != nil {
+		exit.Usage("%v", err)
+	}
+	images, err := parseAddonValues(addonImages)
+	if err != nil {
+		exit.Usage("%v", err)
+	}
+
+	cc, err := pkgConfig.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			exit.WithCode(exit.Data, "The %q profile does not exist, run minikube start first", pkgConfig.GetMachineName())
+		}
+		exit.WithError("Error loading profile config", err)
+	}
+	if err := addon.ValidateImages(cc.KubernetesConfig, images); err != nil {
+		exit.Usage("%v", err)
+	}
+	if len(values) > 0 {
+		cc.KubernetesConfig.SetAddonValues(name, values)
+	}
+	if addonRegistry != "" || len(images) > 0 {
+		cc.KubernetesConfig.SetAddonImages(name, addonRegistry, images)
+	}
+	if err := pkgConfig.SaveProfile(pkgConfig.GetMachineName(), cc); err != nil {
+		exit.WithError("Error saving profile config", err)
+	}
+
+	enabled, err := addon.IsEnabled()
+	if err != nil {
+		exit.WithError("IsEnabled failed", err)
+	}
+	if enabled && isMinikubeRunning() {
+		if err := EnableOrDisableAddon(name, "true"); err != nil {
+			exit.WithError("Error applying the addon configuration", err)
+		}
+	} else {
+		console.OutStyle("tip", "The configuration will be applied the next time %s is enabled", name)
+	}
+	console.Success("%s was successfully configured", name)
+}
+
+// parseAddonValues parses KEY=VALUE pairs, such as values or ORIGINAL=IMAGE overrides
+func parseAddonValues(pairs []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, p := range pairs {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid pair %q, expected KEY=VALUE", p)
+		}
+		values[kv[0]] = kv[1]
+	}
+	return values, nil
+}
+
+// isMinikubeRunning returns whether the host of the profile is running
+func isMinikubeRunning() bool {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		exit.WithError("Error getting client", err)
+	}
+	defer api.Close()
+	s, err := cluster.GetHostStatus(api, pkgConfig.GetMachineName())
+	if err != nil {
+		exit.WithError("Error getting machine status", err)
+	}
+	return s == state.Running.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func init() {
+	addonsConfigureCmd.Flags().StringArrayVar(&addonValues, "set", nil, "Set a value of the addon, as KEY=VALUE (can be repeated)")
+	addonsConfigureCmd.Flags().StringVar(&addonRegistry, "registry", "", "Pull every image of the addon from this registry, e.g. a mirror")
+	addonsConfigureCmd.Flags().StringArrayVar(&addonImages, "image", nil, "Replace an image of the addon, as ORIGINAL=IMAGE (can be repeated)")
 	AddonsCmd.AddCommand(addonsConfigureCmd)
 }
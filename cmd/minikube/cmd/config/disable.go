@@ -17,7 +17,11 @@ limitations under the License.
 package config
 
 import (
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/exit"
 )
@@ -36,10 +40,32 @@ var addonsDisableCmd = &cobra.Command{
 		if err != nil {
 			exit.WithError("disable failed", err)
 		}
+		dependents, err := enabledDependents(addon)
+		if err != nil {
+			exit.WithError("Error checking the addons which require it", err)
+		}
+		if len(dependents) > 0 {
+			console.OutStyle("warning", "%s is required by the enabled addons: %s", addon, strings.Join(dependents, ", "))
+		}
 		console.Success("%s was successfully disabled", addon)
 	},
 }
 
+// enabledDependents returns the enabled addons which require an addon
+func enabledDependents(name string) ([]string, error) {
+	var dependents []string
+	for _, d := range assets.RequiredBy(name) {
+		enabled, err := assets.Addons[d].IsEnabled()
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking if %s is enabled", d)
+		}
+		if enabled {
+			dependents = append(dependents, d)
+		}
+	}
+	return dependents, nil
+}
+
 func init() {
 	AddonsCmd.AddCommand(addonsDisableCmd)
 }
@@ -17,30 +17,120 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/util"
 )
 
+var waitForAddon bool
+
 var addonsEnableCmd = &cobra.Command{
 	Use:   "enable ADDON_NAME",
 	Short: "Enables the addon w/ADDON_NAME within minikube (example: minikube addons enable dashboard). For a list of available addons use: minikube addons list ",
-	Long:  "Enables the addon w/ADDON_NAME within minikube (example: minikube addons enable dashboard). For a list of available addons use: minikube addons list ",
+	Long:  "Enables the addon w/ADDON_NAME within minikube (example: minikube addons enable dashboard), after the addons it requires. For a list of available addons use: minikube addons list ",
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 1 {
 			exit.Usage("usage: minikube addons enable ADDON_NAME")
 		}
 
 		addon := args[0]
-		err := Set(addon, "true")
+		order, err := assets.EnableOrder(addon)
+		if err != nil {
+			exit.WithCode(exit.Data, "%v", err)
+		}
+		if err := checkAddonConflicts(order); err != nil {
+			exit.WithCode(exit.Config, "%v", err)
+		}
+		for _, required := range order[:len(order)-1] {
+			enabled, err := assets.Addons[required].IsEnabled()
+			if err != nil {
+				exit.WithError("IsEnabled failed", err)
+			}
+			if enabled {
+				continue
+			}
+			console.OutStyle("enabling", "Enabling %s, which is required by %s", required, addon)
+			if err := Set(required, "true"); err != nil {
+				exit.WithError("Error enabling a required addon", err)
+			}
+			if waitForAddon {
+				if err := waitForAddonPods(required); err != nil {
+					exit.WithError("Error waiting for the required addon to be ready", err)
+				}
+			}
+		}
+		err = Set(addon, "true")
 		if err != nil {
 			console.Fatal("enable failed: %v", err)
-		} else {
-			console.Success("%s was successfully enabled", addon)
+			return
 		}
+		if waitForAddon {
+			if err := waitForAddonPods(addon); err != nil {
+				exit.WithError("Error waiting for the addon to be ready", err)
+			}
+		}
+		console.Success("%s was successfully enabled", addon)
 	},
 }
 
+// checkAddonConflicts returns an error if any of the addons to enable conflicts with another one of them,
+// or with an enabled addon
+func checkAddonConflicts(names []string) error {
+	var enabled []string
+	for name, addon := range assets.Addons {
+		isEnabled, err := addon.IsEnabled()
+		if err != nil {
+			return errors.Wrapf(err, "checking if %s is enabled", name)
+		}
+		if isEnabled {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+	for i, name := range names {
+		addon := assets.Addons[name]
+		for _, other := range names[:i] {
+			if addon.ConflictsWith(other) {
+				return fmt.Errorf("%s conflicts with %s, they cannot be enabled together", name, other)
+			}
+		}
+		for _, other := range enabled {
+			if other != name && addon.ConflictsWith(other) {
+				return fmt.Errorf("%s conflicts with %s, which is enabled. Disable it first with: minikube addons disable %s", name, other, other)
+			}
+		}
+	}
+	return nil
+}
+
+// waitForAddonPods waits until the pods of an addon are running
+func waitForAddonPods(name string) error {
+	addon := assets.Addons[name]
+	if addon.Pods == nil {
+		glog.Infof("%s has no pods to wait for", name)
+		return nil
+	}
+	selector, err := labels.Parse(addon.Pods.Selector)
+	if err != nil {
+		return errors.Wrapf(err, "parsing pod selector of %s", name)
+	}
+	client, err := util.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "getting kubernetes client")
+	}
+	console.OutStyle("waiting", "Waiting for the pods of %s to be running ...", name)
+	return util.WaitForPodsWithLabelRunning(client, addon.Pods.Namespace, selector)
+}
+
 func init() {
+	addonsEnableCmd.Flags().BoolVar(&waitForAddon, "wait", false, "Wait until the pods of the addon are running")
 	AddonsCmd.AddCommand(addonsEnableCmd)
 }
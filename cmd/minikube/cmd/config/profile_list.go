@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	pkgConfig "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+var profileOutput string
+
+// ProfileStatus represents a profile and the state of its host, as printed by "profile list -o json"
+type ProfileStatus struct {
+	Name              string
+	Active            bool
+	Status            string
+	VMDriver          string `json:",omitempty"`
+	ContainerRuntime  string `json:",omitempty"`
+	KubernetesVersion string `json:",omitempty"`
+	IP                string `json:",omitempty"`
+	Port              int    `json:",omitempty"`
+	Nodes             int    `json:",omitempty"`
+}
+
+// ProfileList represents the output of "profile list -o json"
+type ProfileList struct {
+	Valid   []ProfileStatus
+	Invalid []ProfileStatus
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all minikube profiles.",
+	Long:  "Lists all valid minikube profiles and detects all possible invalid profiles.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			exit.Usage("usage: minikube profile list")
+		}
+		valid, invalid, err := pkgConfig.ListProfiles()
+		if err != nil {
+			exit.WithError("Failed to list profiles", err)
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+
+		list := ProfileList{
+			Valid:   profileStatuses(api, valid),
+			Invalid: profileStatuses(api, invalid),
+		}
+		switch profileOutput {
+		case "json":
+			printProfilesJSON(list)
+		case "table":
+			printProfilesTable(list)
+		default:
+			exit.Usage("invalid output format: %s. Valid values: 'table', 'json'", profileOutput)
+		}
+	},
+}
+
+func profileStatuses(api libmachine.API, profiles []*pkgConfig.Profile) []ProfileStatus {
+	active := pkgConfig.GetMachineName()
+	statuses := []ProfileStatus{}
+	for _, p := range profiles {
+		ps := ProfileStatus{Name: p.Name, Active: p.Name == active}
+		s, err := cluster.GetHostStatus(api, p.Name)
+		if err != nil {
+			glog.Warningf("error getting host status for %s: %v", p.Name, err)
+			s = "Error"
+		}
+		ps.Status = s
+		if p.Config != nil {
+			ps.VMDriver = p.Config.MachineConfig.VMDriver
+			ps.ContainerRuntime = p.Config.KubernetesConfig.ContainerRuntime
+			ps.KubernetesVersion = p.Config.KubernetesConfig.KubernetesVersion
+			ps.IP = p.Config.KubernetesConfig.NodeIP
+			ps.Port = p.Config.KubernetesConfig.NodePort
+			ps.Nodes = len(p.Config.Nodes) + 1
+		}
+		statuses = append(statuses, ps)
+	}
+	return statuses
+}
+
+func printProfilesTable(list ProfileList) {
+	var data [][]string
+	for _, p := range list.Valid {
+		name := p.Name
+		if p.Active {
+			name = "*" + name
+		}
+		data = append(data, []string{name, p.VMDriver, p.ContainerRuntime, p.KubernetesVersion, p.IP, strconv.Itoa(p.Port), strconv.Itoa(p.Nodes), p.Status})
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Profile", "VM Driver", "Runtime", "Kubernetes", "IP", "Port", "Nodes", "Status"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(data)
+	table.Render()
+
+	for _, p := range list.Invalid {
+		console.ErrStyle("warning", "Found an invalid profile: %s (status: %s)", p.Name, p.Status)
+	}
+	if len(list.Invalid) > 0 {
+		console.ErrStyle("tip", "You can delete invalid profiles with: minikube delete -p <name>")
+	}
+}
+
+func printProfilesJSON(list ProfileList) {
+	out, err := json.Marshal(list)
+	if err != nil {
+		exit.WithError("Error encoding profiles", err)
+	}
+	console.OutLn("%s", out)
+}
+
+func init() {
+	profileListCmd.Flags().StringVarP(&profileOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	ProfileCmd.AddCommand(profileListCmd)
+}
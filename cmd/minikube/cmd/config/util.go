@@ -23,10 +23,12 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/addons"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/machine"
@@ -54,6 +56,15 @@ func findSetting(name string) (Setting, error) {
 			return s, nil
 		}
 	}
+	// third-party addons have no predefined setting
+	if _, ok := assets.Addons[name]; ok {
+		return Setting{
+			name:        name,
+			set:         SetBool,
+			validations: []setFn{IsValidAddon},
+			callbacks:   []setFn{EnableOrDisableAddon},
+		}, nil
+	}
 	return Setting{}, fmt.Errorf("Property name %s not found", name)
 }
 
@@ -132,42 +143,77 @@ func EnableOrDisableAddon(name string, val string) error {
 		exit.WithCode(exit.Data, "Unable to load config: %v", err)
 	}
 
-	data := assets.GenerateTemplateData(cfg.KubernetesConfig)
-	return enableOrDisableAddonInternal(addon, cmd, data, enable)
-}
+	if enable && len(addon.Images) > 0 {
+		images, err := addon.ImagesFor(cfg.KubernetesConfig)
+		if err != nil {
+			return errors.Wrapf(err, "images of addon %s", name)
+		}
+		if err := machine.CacheAndLoadImages(images); err != nil {
+			return errors.Wrapf(err, "caching images of addon %s", name)
+		}
+	}
 
-func enableOrDisableAddonInternal(addon *assets.Addon, cmd bootstrapper.CommandRunner, data interface{}, enable bool) error {
-	var err error
-	if enable {
-		for _, addon := range addon.Assets {
-			var addonFile assets.CopyableFile
-			if addon.IsTemplate() {
-				addonFile, err = addon.Evaluate(data)
-				if err != nil {
-					return errors.Wrapf(err, "evaluate bundled addon %s asset", addon.GetAssetName())
-				}
-
-			} else {
-				addonFile = addon
-			}
-			if err := cmd.Copy(addonFile); err != nil {
-				return errors.Wrapf(err, "enabling addon %s", addon.AssetName)
-			}
+	files, err := addon.RenderAssets(cfg.KubernetesConfig)
+	if err != nil {
+		return errors.Wrapf(err, "rendering addon %s", name)
+	}
+
+	// without the addon manager, nothing applies the files copied to the node
+	direct := false
+	if name != "addon-manager" {
+		managed, err := assets.Addons["addon-manager"].IsEnabled()
+		if err != nil {
+			return errors.Wrap(err, "checking if addon-manager is enabled")
 		}
-	} else {
-		for _, addon := range addon.Assets {
-			var addonFile assets.CopyableFile
-			if addon.IsTemplate() {
-				addonFile, err = addon.Evaluate(data)
-				if err != nil {
-					return errors.Wrapf(err, "evaluate bundled addon %s asset", addon.GetAssetName())
-				}
-
-			} else {
-				addonFile = addon
+		direct = !managed
+	}
+	version := cfg.KubernetesConfig.KubernetesVersion
+	if direct {
+		if err := addons.InstallKubectl(cmd, version); err != nil {
+			return errors.Wrap(err, "installing kubectl")
+		}
+	}
+	if direct && !enable {
+		console.OutStyle("shutdown", "Deleting the resources of %s, as addon-manager is disabled", name)
+		results, err := addons.Delete(cmd, version, files)
+		printApplyResults(results)
+		if err != nil {
+			return errors.Wrapf(err, "deleting addon %s", name)
+		}
+	}
+	if err := enableOrDisableAddonInternal(files, cmd, enable); err != nil {
+		return err
+	}
+	if direct && enable {
+		console.OutStyle("enabling", "Applying %s, as addon-manager is disabled", name)
+		results, err := addons.Apply(cmd, version, files)
+		printApplyResults(results)
+		if err != nil {
+			return errors.Wrapf(err, "applying addon %s", name)
+		}
+	}
+	return nil
+}
+
+// printApplyResults prints what kubectl did to the resources of an addon, and anything else it said
+func printApplyResults(results *addons.Results) {
+	for _, r := range results.Resources {
+		console.OutStyle("option", "%s %s", r.Resource, r.Action)
+	}
+	for _, m := range results.Messages {
+		console.ErrStyle("failure", "%s", m)
+	}
+}
+
+func enableOrDisableAddonInternal(files []assets.CopyableFile, cmd bootstrapper.CommandRunner, enable bool) error {
+	for _, f := range files {
+		if enable {
+			if err := cmd.Copy(f); err != nil {
+				return errors.Wrapf(err, "enabling addon %s", f.GetAssetName())
 			}
-			if err := cmd.Remove(addonFile); err != nil {
-				return errors.Wrapf(err, "disabling addon %s", addon.AssetName)
+		} else {
+			if err := cmd.Remove(f); err != nil {
+				return errors.Wrapf(err, "disabling addon %s", f.GetAssetName())
 			}
 		}
 	}
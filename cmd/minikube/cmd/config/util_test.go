@@ -19,6 +19,7 @@ package config
 import (
 	"testing"
 
+	"k8s.io/minikube/pkg/minikube/assets"
 	pkgConfig "k8s.io/minikube/pkg/minikube/config"
 )
 
@@ -45,6 +46,18 @@ func TestFindSetting(t *testing.T) {
 	}
 }
 
+func TestFindSettingThirdPartyAddon(t *testing.T) {
+	assets.Addons["third-party"] = assets.NewAddon(nil, false, "third-party")
+	defer delete(assets.Addons, "third-party")
+	s, err := findSetting("third-party")
+	if err != nil {
+		t.Fatalf("Couldn't find setting for a third-party addon: %v", err)
+	}
+	if s.name != "third-party" || len(s.callbacks) != 1 {
+		t.Fatalf("Unexpected setting for a third-party addon: %+v", s)
+	}
+}
+
 func TestSetString(t *testing.T) {
 	err := SetString(minikubeConfig, "vm-driver", "virtualbox")
 	if err != nil {
@@ -70,7 +70,18 @@ associated files.`,
 			}
 		}
 
-		if err = cluster.DeleteHost(api); err != nil {
+		if cc != nil {
+			for _, n := range cc.Nodes {
+				name := n.MachineName(profile)
+				if err := cluster.DeleteHost(api, name); err != nil {
+					if _, ok := errors.Cause(err).(mcnerror.ErrHostDoesNotExist); !ok {
+						console.ErrLn("Failed to delete node %q: %v", name, err)
+					}
+				}
+			}
+		}
+
+		if err = cluster.DeleteHost(api, profile); err != nil {
 			switch err := errors.Cause(err).(type) {
 			case mcnerror.ErrHostDoesNotExist:
 				console.OutStyle("meh", "%q cluster does not exist", profile)
@@ -348,7 +348,7 @@ var dockerEnvCmd = &cobra.Command{
 		if host.Driver.DriverName() == "none" {
 			exit.Usage(`'none' driver does not support 'minikube docker-env' command`)
 		}
-		hostSt, err := cluster.GetHostStatus(api)
+		hostSt, err := cluster.GetHostStatus(api, config.GetMachineName())
 		if err != nil {
 			exit.WithError("Error getting host status", err)
 		}
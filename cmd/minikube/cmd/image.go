@@ -0,0 +1,171 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// imageCmd represents the image command
+var imageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Load, list or remove images in the container runtime of the cluster.",
+	Long: `Load, list or remove images in the container runtime of the cluster. Unlike "minikube cache",
+these commands act on the nodes of the running cluster directly, and do not change the local cache.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// loadImageCmd represents the image load command
+var loadImageCmd = &cobra.Command{
+	Use:   "load IMAGE|TARBALL ...",
+	Short: "Loads images into the container runtime of the cluster.",
+	Long: `Loads images into the container runtime of every node of the cluster. Each argument is either
+the path to an image tarball, or the name of an image in the docker daemon of the host.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.Usage("usage: minikube image load IMAGE|TARBALL ...")
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		tmp, err := ioutil.TempDir("", "minikube-image")
+		if err != nil {
+			exit.WithError("Error creating temporary directory", err)
+		}
+		defer os.RemoveAll(tmp)
+
+		var paths []string
+		for i, arg := range args {
+			if fi, err := os.Stat(arg); err == nil && !fi.IsDir() {
+				paths = append(paths, arg)
+				continue
+			}
+			dst := filepath.Join(tmp, fmt.Sprintf("%d-%s.tar", i, strings.Replace(filepath.Base(arg), ":", "_", -1)))
+			console.OutStyle("pulling", "Saving %q from the docker daemon ...", arg)
+			if err := machine.SaveDaemonImage(arg, dst); err != nil {
+				exit.WithError("Failed to save image", err)
+			}
+			paths = append(paths, dst)
+		}
+
+		err = forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+			for i, p := range paths {
+				console.OutStyle("option", "Loading %s into %q", args[i], name)
+				if err := machine.LoadImageFile(r, cr, p); err != nil {
+					return errors.Wrapf(err, "loading %s", args[i])
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			exit.WithError("Failed to load images", err)
+		}
+		console.OutStyle("success", "Loaded %d images into %q", len(paths), cfg.GetMachineName())
+	},
+}
+
+// removeImageCmd represents the image rm command
+var removeImageCmd = &cobra.Command{
+	Use:   "rm IMAGE ...",
+	Short: "Removes images from the container runtime of the cluster.",
+	Long:  "Removes images from the container runtime of every node of the cluster which has them.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			exit.Usage("usage: minikube image rm IMAGE ...")
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		found := map[string]bool{}
+		err = forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+			images, err := cr.ListImages()
+			if err != nil {
+				return errors.Wrap(err, "listing images")
+			}
+			for _, arg := range args {
+				if !containsImage(images, arg) {
+					continue
+				}
+				if err := cr.RemoveImage(arg); err != nil {
+					return errors.Wrapf(err, "removing %s", arg)
+				}
+				found[arg] = true
+				console.OutStyle("option", "Removed %s from %q", arg, name)
+			}
+			return nil
+		})
+		if err != nil {
+			exit.WithError("Failed to remove images", err)
+		}
+		for _, arg := range args {
+			if !found[arg] {
+				console.OutStyle("meh", "Image %q was not found in %q", arg, cfg.GetMachineName())
+			}
+		}
+	},
+}
+
+// containsImage returns whether an image is in a list of image names, ignoring the default registry and tag
+func containsImage(images []string, image string) bool {
+	for _, i := range images {
+		if normalizeImage(i) == normalizeImage(image) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeImage strips the Docker Hub prefixes from an image name, and adds the latest tag if it has none
+func normalizeImage(image string) string {
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "library/")
+	if !strings.Contains(path.Base(image), ":") && !strings.Contains(image, "@") {
+		image += ":latest"
+	}
+	return image
+}
+
+func init() {
+	imageCmd.AddCommand(loadImageCmd)
+	imageCmd.AddCommand(removeImageCmd)
+	RootCmd.AddCommand(imageCmd)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+var (
+	buildTag  string
+	buildFile string
+)
+
+// buildImageCmd represents the image build command
+var buildImageCmd = &cobra.Command{
+	Use:   "build CONTEXT_DIR",
+	Short: "Builds an image in the container runtime of the cluster.",
+	Long: `Builds an image from a local context directory, using the container runtime of every node of the cluster:
+docker build for docker, podman for cri-o, and buildkit for containerd. Pods can use the image with "imagePullPolicy: Never".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Usage("usage: minikube image build CONTEXT_DIR -t TAG")
+		}
+		if buildTag == "" {
+			exit.Usage("An image tag must be given with --tag")
+		}
+		contextDir := args[0]
+		if fi, err := os.Stat(contextDir); err != nil || !fi.IsDir() {
+			exit.WithCode(exit.NoInput, "%q is not a directory", contextDir)
+		}
+		if _, err := os.Stat(filepath.Join(contextDir, buildFile)); err != nil {
+			exit.WithCode(exit.NoInput, "%q was not found in %q", buildFile, contextDir)
+		}
+
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		err = forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+			console.OutStyle("option", "Building %s in %q using %s", buildTag, name, cr.Name())
+			return errors.Wrap(machine.BuildImage(r, cr, contextDir, buildFile, buildTag), "build")
+		})
+		if err != nil {
+			exit.WithError("Failed to build image", err)
+		}
+		console.OutStyle("success", "Built %s in %q", buildTag, cfg.GetMachineName())
+	},
+}
+
+func init() {
+	buildImageCmd.Flags().StringVarP(&buildTag, "tag", "t", "", "Name and tag of the image to build, in the 'name:tag' format")
+	buildImageCmd.Flags().StringVarP(&buildFile, "file", "f", "Dockerfile", "Path of the Dockerfile, relative to the context directory")
+	imageCmd.AddCommand(buildImageCmd)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// listImageCmd represents the image ls command
+var listImageCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "Lists the images in the container runtime of the cluster.",
+	Long:    "Lists the tagged images present in the container runtime of the control plane node.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cc, err := cfg.Load()
+		if err != nil {
+			if os.IsNotExist(err) {
+				exit.WithCode(exit.NoInput, "%q cluster does not exist", cfg.GetMachineName())
+			}
+			exit.WithError("Error loading profile config", err)
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		h, err := api.Load(cfg.GetMachineName())
+		if err != nil {
+			exit.WithError("Error getting host", err)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			exit.WithError("Failed to get command runner", err)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Socket: cc.KubernetesConfig.CRISocket, Runner: runner})
+		if err != nil {
+			exit.WithError("Failed runtime", err)
+		}
+		images, err := cr.ListImages()
+		if err != nil {
+			exit.WithError("Failed to list images", err)
+		}
+		sort.Strings(images)
+		for _, i := range images {
+			console.OutLn("%s", i)
+		}
+	},
+}
+
+func init() {
+	imageCmd.AddCommand(listImageCmd)
+}
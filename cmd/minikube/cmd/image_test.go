@@ -0,0 +1,41 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import "testing"
+
+func TestContainsImage(t *testing.T) {
+	images := []string{"docker.io/library/busybox:latest", "k8s.gcr.io/pause:3.1", "localhost:5000/app:v1"}
+	var tests = []struct {
+		image string
+		want  bool
+	}{
+		{"busybox", true},
+		{"busybox:latest", true},
+		{"docker.io/library/busybox:latest", true},
+		{"busybox:1.30", false},
+		{"k8s.gcr.io/pause:3.1", true},
+		{"k8s.gcr.io/pause", false},
+		{"localhost:5000/app:v1", true},
+		{"localhost:5000/app", false},
+	}
+	for _, tc := range tests {
+		if got := containsImage(images, tc.image); got != tc.want {
+			t.Errorf("containsImage(%q) = %v, want %v", tc.image, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/host"
+	"github.com/docker/machine/libmachine/mcnerror"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	pkgutil "k8s.io/minikube/pkg/util"
+)
+
+// nodeCmd represents the node command
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Add, delete or list the worker nodes of a cluster.",
+	Long:  "Add, delete or list the worker nodes of a cluster. Worker nodes are additional VMs which join the cluster of the current profile.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// addNodeCmd represents the node add command
+var addNodeCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds a worker node to the cluster.",
+	Long:  "Creates a new VM using the configuration of the current profile, and joins it to the cluster as a worker node.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			exit.Usage("usage: minikube node add")
+		}
+		cc := loadNodeClusterConfig()
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		bs, err := GetClusterBootstrapper(api, viper.GetString(cmdcfg.Bootstrapper))
+		if err != nil {
+			exit.WithError("Error getting bootstrapper", err)
+		}
+
+		n := cfg.Node{Name: cc.NextNodeName()}
+		console.OutStyle("starting-vm", "Adding node %q to cluster %q ...", n.Name, cfg.GetMachineName())
+		// Record the node before creating it, so that a failed join can still be cleaned up with "node delete".
+		cc.Nodes = append(cc.Nodes, n)
+		if err := cfg.SaveProfile(cfg.GetMachineName(), cc); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+		if err := startNode(api, bs, cc, &cc.Nodes[len(cc.Nodes)-1]); err != nil {
+			exit.WithError("Failed to add node", err)
+		}
+		if err := cfg.SaveProfile(cfg.GetMachineName(), cc); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+		console.OutStyle("ready", "Node %q has joined the cluster.", n.Name)
+	},
+}
+
+// deleteNodeCmd represents the node delete command
+var deleteNodeCmd = &cobra.Command{
+	Use:   "delete NODE_NAME",
+	Short: "Deletes a worker node from the cluster.",
+	Long:  "Removes a worker node from the cluster, and deletes its VM.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Usage("usage: minikube node delete NODE_NAME")
+		}
+		cc := loadNodeClusterConfig()
+		i := cc.FindNode(args[0])
+		if i == -1 {
+			exit.WithCode(exit.NoInput, "%q is not a node of cluster %q", args[0], cfg.GetMachineName())
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+
+		if err := deleteNode(api, cc, cc.Nodes[i]); err != nil {
+			exit.WithError("Failed to delete node", err)
+		}
+		cc.Nodes = append(cc.Nodes[:i], cc.Nodes[i+1:]...)
+		if err := cfg.SaveProfile(cfg.GetMachineName(), cc); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+		console.OutStyle("crushed", "Node %q has been deleted.", args[0])
+	},
+}
+
+// loadNodeClusterConfig loads the config of the current profile, which must exist and use a VM
+func loadNodeClusterConfig() *cfg.Config {
+	cc, err := cfg.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			exit.WithCode(exit.NoInput, "%q cluster does not exist", cfg.GetMachineName())
+		}
+		exit.WithError("Error loading profile config", err)
+	}
+	if cc.MachineConfig.VMDriver == constants.DriverNone {
+		exit.Usage("The 'none' driver does not support multiple nodes")
+	}
+	return cc
+}
+
+// startNode starts the VM of a worker node, creating it if necessary, and joins it to the cluster
+func startNode(api libmachine.API, bs bootstrapper.Bootstrapper, cc *cfg.Config, n *cfg.Node) error {
+	mc := cluster.NodeMachineConfig(cc.MachineConfig, n.MachineName(cfg.GetMachineName()))
+
+	var h *host.Host
+	start := func() (err error) {
+		h, err = cluster.StartHost(api, mc)
+		if err != nil {
+			glog.Infof("StartHost: %v", err)
+		}
+		return err
+	}
+	if err := pkgutil.RetryAfter(3, start, 2*time.Second); err != nil {
+		return errors.Wrap(err, "starting host")
+	}
+
+	ip, err := h.Driver.GetIP()
+	if err != nil {
+		return errors.Wrap(err, "getting IP")
+	}
+	n.IP = ip
+
+	runner, err := machine.CommandRunner(h)
+	if err != nil {
+		return errors.Wrap(err, "command runner")
+	}
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+	if err := cr.Enable(); err != nil {
+		return errors.Wrap(err, "enabling container runtime")
+	}
+
+	console.OutStyle("launch", "Joining %q to the cluster ...", mc.Name)
+	return bs.JoinNode(cc.KubernetesConfig, mc.Name, runner)
+}
+
+// deleteNode removes a worker node from the cluster and deletes its VM
+func deleteNode(api libmachine.API, cc *cfg.Config, n cfg.Node) error {
+	name := n.MachineName(cfg.GetMachineName())
+	bs, err := GetClusterBootstrapper(api, viper.GetString(cmdcfg.Bootstrapper))
+	if err != nil {
+		console.ErrLn("Unable to get bootstrapper: %v", err)
+	} else if err := bs.RemoveNode(cc.KubernetesConfig, name); err != nil {
+		// The control plane may already be gone, which should not prevent deleting the VM.
+		console.ErrLn("Unable to remove %q from the cluster: %v", name, err)
+	}
+
+	if err := cluster.DeleteHost(api, name); err != nil {
+		if _, ok := errors.Cause(err).(mcnerror.ErrHostDoesNotExist); !ok {
+			return err
+		}
+		console.OutStyle("meh", "%q VM does not exist", name)
+	}
+	return nil
+}
+
+func init() {
+	nodeCmd.AddCommand(addNodeCmd)
+	nodeCmd.AddCommand(deleteNodeCmd)
+	RootCmd.AddCommand(nodeCmd)
+}
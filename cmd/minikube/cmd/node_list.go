@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// listNodeCmd represents the node list command
+var listNodeCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the nodes of the cluster.",
+	Long:  "Lists the control plane and worker nodes of the cluster, along with the state of their VMs.",
+	Run: func(cmd *cobra.Command, args []string) {
+		cc := loadNodeClusterConfig()
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+
+		profile := cfg.GetMachineName()
+		hostState := func(name string) string {
+			s, err := cluster.GetHostStatus(api, name)
+			if err != nil {
+				glog.Warningf("error getting status of %s: %v", name, err)
+				return "Error"
+			}
+			return s
+		}
+
+		data := [][]string{{profile, profile, "control-plane", cc.KubernetesConfig.NodeIP, hostState(profile)}}
+		for _, n := range cc.Nodes {
+			name := n.MachineName(profile)
+			data = append(data, []string{n.Name, name, "worker", n.IP, hostState(name)})
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Machine", "Role", "IP", "Status"})
+		table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+		table.SetCenterSeparator("|")
+		table.AppendBulk(data)
+		table.Render()
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(listNodeCmd)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// pauseCmd represents the pause command
+var pauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pauses the Kubernetes containers of a running cluster",
+	Long: `Pauses the Kubernetes containers of a running cluster, and stops kubelet. The VM keeps running,
+but no longer consumes CPU for the cluster. The cluster can be resumed with the "unpause" command.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		count := 0
+		err = forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+			ids, err := cluster.Pause(cr, r)
+			count += len(ids)
+			return err
+		})
+		if err != nil {
+			exit.WithError("Pause", err)
+		}
+		console.OutStyle("paused", "Paused %d containers in %q", count, cfg.GetMachineName())
+	},
+}
+
+// forEachClusterMachine calls fn with the container runtime of every running machine of the current profile
+func forEachClusterMachine(api libmachine.API, fn func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error) error {
+	cc, err := cfg.Load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			exit.WithCode(exit.NoInput, "%q cluster does not exist", cfg.GetMachineName())
+		}
+		exit.WithError("Error loading profile config", err)
+	}
+
+	names := []string{cfg.GetMachineName()}
+	for _, n := range cc.Nodes {
+		names = append(names, n.MachineName(cfg.GetMachineName()))
+	}
+	for _, name := range names {
+		s, err := cluster.GetHostStatus(api, name)
+		if err != nil {
+			return errors.Wrapf(err, "status of %s", name)
+		}
+		if s != state.Running.String() {
+			console.OutStyle("meh", "%q is not running, skipping", name)
+			continue
+		}
+		h, err := api.Load(name)
+		if err != nil {
+			return errors.Wrapf(err, "load %s", name)
+		}
+		r, err := machine.CommandRunner(h)
+		if err != nil {
+			return errors.Wrapf(err, "command runner for %s", name)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Socket: cc.KubernetesConfig.CRISocket, Runner: r})
+		if err != nil {
+			return errors.Wrap(err, "runtime")
+		}
+		if err := fn(name, cr, r); err != nil {
+			return errors.Wrap(err, name)
+		}
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(pauseCmd)
+}
@@ -31,9 +31,12 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	configCmd "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/k3s"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/kubeadm"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/notify"
@@ -47,6 +50,7 @@ var dirs = [...]string{
 	constants.MakeMiniPath("cache", "iso"),
 	constants.MakeMiniPath("config"),
 	constants.MakeMiniPath("addons"),
+	constants.ThirdPartyAddonsPath(),
 	constants.MakeMiniPath("files"),
 	constants.MakeMiniPath("logs"),
 }
@@ -73,6 +77,10 @@ var RootCmd = &cobra.Command{
 			}
 		}
 
+		if err := assets.LoadThirdPartyAddons(constants.ThirdPartyAddonsPath()); err != nil {
+			console.Warning("%v", err)
+		}
+
 		// Log level 3 or greater enables libmachine logs
 		if !glog.V(3) {
 			log.SetOutWriter(ioutil.Discard)
@@ -124,7 +132,7 @@ func setFlagsUsingViper() {
 func init() {
 	RootCmd.PersistentFlags().StringP(config.MachineProfile, "p", constants.DefaultMachineName, `The name of the minikube VM being used.  
 	This can be modified to allow for multiple minikube instances to be run independently`)
-	RootCmd.PersistentFlags().StringP(configCmd.Bootstrapper, "b", constants.DefaultClusterBootstrapper, "The name of the cluster bootstrapper that will set up the kubernetes cluster.")
+	RootCmd.PersistentFlags().StringP(configCmd.Bootstrapper, "b", constants.DefaultClusterBootstrapper, "The name of the cluster bootstrapper that will set up the kubernetes cluster: kubeadm or k3s.")
 	RootCmd.AddCommand(configCmd.ConfigCmd)
 	RootCmd.AddCommand(configCmd.AddonsCmd)
 	RootCmd.AddCommand(configCmd.ProfileCmd)
@@ -175,6 +183,11 @@ func GetClusterBootstrapper(api libmachine.API, bootstrapperName string) (bootst
 		if err != nil {
 			return nil, errors.Wrap(err, "getting kubeadm bootstrapper")
 		}
+	case bootstrapper.BootstrapperTypeK3s:
+		b, err = k3s.NewK3sBootstrapper(api)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting k3s bootstrapper")
+		}
 	default:
 		return nil, fmt.Errorf("Unknown bootstrapper: %s", bootstrapperName)
 	}
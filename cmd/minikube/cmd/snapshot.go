@@ -0,0 +1,212 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/logs"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/snapshot"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save, restore or list snapshots of the cluster state.",
+	Long: `Save, restore or list snapshots of the cluster state. A snapshot contains the etcd data and certificates
+of the cluster, along with the configuration of the profile, and is stored in the minikube home directory.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// saveSnapshotCmd represents the snapshot save command
+var saveSnapshotCmd = &cobra.Command{
+	Use:   "save SNAPSHOT_NAME",
+	Short: "Saves a snapshot of the cluster state.",
+	Long:  "Saves a snapshot of the cluster state, replacing any existing snapshot with the same name. The cluster is paused while its state is captured.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Usage("usage: minikube snapshot save SNAPSHOT_NAME")
+		}
+		name := args[0]
+		profile := cfg.GetMachineName()
+		cc, err := cfg.Load()
+		if err != nil {
+			if os.IsNotExist(err) {
+				exit.WithCode(exit.NoInput, "%q cluster does not exist", profile)
+			}
+			exit.WithError("Error loading profile config", err)
+		}
+		if cc.MachineConfig.VMDriver == constants.DriverNone {
+			exit.Usage("The 'none' driver does not support snapshots")
+		}
+
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		h, err := api.Load(profile)
+		if err != nil {
+			exit.WithError("Error getting host", err)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			exit.WithError("Failed to get command runner", err)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Socket: cc.KubernetesConfig.CRISocket, Runner: runner})
+		if err != nil {
+			exit.WithError("Failed runtime", err)
+		}
+		bs, err := GetClusterBootstrapper(api, viper.GetString(cmdcfg.Bootstrapper))
+		if err != nil {
+			exit.WithError("Error getting bootstrapper", err)
+		}
+
+		console.OutStyle("paused", "Pausing %q to capture its state ...", profile)
+		if _, err := cluster.Pause(cr, runner); err != nil {
+			exit.WithError("Failed to pause cluster", err)
+		}
+		data, err := bs.SaveSnapshot(cc.KubernetesConfig)
+		if _, uerr := cluster.Unpause(cr, runner); uerr != nil {
+			console.ErrLn("Unable to unpause cluster: %v", uerr)
+		}
+		if err != nil {
+			exit.WithError("Failed to save snapshot", err)
+		}
+
+		if err := snapshot.Save(profile, name, cc, data); err != nil {
+			exit.WithError("Failed to store snapshot", err)
+		}
+		console.OutStyle("success", "Saved snapshot %q of %q", name, profile)
+	},
+}
+
+// restoreSnapshotCmd represents the snapshot restore command
+var restoreSnapshotCmd = &cobra.Command{
+	Use:   "restore SNAPSHOT_NAME",
+	Short: "Restores the cluster state from a snapshot.",
+	Long: `Restores the cluster state from a snapshot. The VM of the profile is created if it does not exist,
+and the profile configuration is replaced by the one stored in the snapshot.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Usage("usage: minikube snapshot restore SNAPSHOT_NAME")
+		}
+		name := args[0]
+		profile := cfg.GetMachineName()
+		cc, data, err := snapshot.Load(profile, name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				exit.WithCode(exit.NoInput, "snapshot %q of %q does not exist", name, profile)
+			}
+			exit.WithError("Error loading snapshot", err)
+		}
+
+		// startHost requires the config to be saved, see runStart
+		if err := saveConfig(*cc); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Failed to get machine client", err)
+		}
+		defer api.Close()
+
+		console.OutStyle("restarting", "Restoring snapshot %q of %q ...", name, profile)
+		h, _ := startHost(api, cc.MachineConfig)
+		ip := validateNetwork(h)
+		cc.KubernetesConfig.NodeIP = ip
+		if err := saveConfig(*cc); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			exit.WithError("Failed to get command runner", err)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Socket: cc.KubernetesConfig.CRISocket, Runner: runner})
+		if err != nil {
+			exit.WithError(fmt.Sprintf("Failed runtime for %s", cc.KubernetesConfig.ContainerRuntime), err)
+		}
+		if err := cr.Enable(); err != nil {
+			exit.WithError("Failed to enable container runtime", err)
+		}
+
+		bs, err := GetClusterBootstrapper(api, viper.GetString(cmdcfg.Bootstrapper))
+		if err != nil {
+			exit.WithError("Failed to get bootstrapper", err)
+		}
+		if err := bs.UpdateCluster(cc.KubernetesConfig); err != nil {
+			exit.WithError("Failed to update cluster", err)
+		}
+		if err := bs.RestoreSnapshot(cc.KubernetesConfig, data); err != nil {
+			exit.WithError("Failed to restore snapshot", err)
+		}
+		// The host IP may have changed, so regenerate the certificates minikube manages on top of the restored ones
+		if err := bs.SetupCerts(cc.KubernetesConfig); err != nil {
+			exit.WithError("Failed to setup certs", err)
+		}
+		kubeconfig := updateKubeConfig(h, cc)
+		if err := bs.RestartCluster(cc.KubernetesConfig); err != nil {
+			exit.WithLogEntries("Error restarting cluster", err, logs.FindProblems(cr, bs, runner))
+		}
+		validateCluster(bs, cr, runner, ip, cc.KubernetesConfig.NodePort)
+		startNodes(api, bs, cc)
+		console.OutStyle("ready", "Restored snapshot %q of %q", name, profile)
+		showKubectlConnectInfo(kubeconfig)
+	},
+}
+
+// deleteSnapshotCmd represents the snapshot delete command
+var deleteSnapshotCmd = &cobra.Command{
+	Use:   "delete SNAPSHOT_NAME",
+	Short: "Deletes a snapshot.",
+	Long:  "Deletes a snapshot of the current profile from the minikube home directory.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Usage("usage: minikube snapshot delete SNAPSHOT_NAME")
+		}
+		profile := cfg.GetMachineName()
+		if err := snapshot.Delete(profile, args[0]); err != nil {
+			if os.IsNotExist(err) {
+				exit.WithCode(exit.NoInput, "snapshot %q of %q does not exist", args[0], profile)
+			}
+			exit.WithError("Failed to delete snapshot", err)
+		}
+		console.OutStyle("crushed", "Deleted snapshot %q of %q", args[0], profile)
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(saveSnapshotCmd)
+	snapshotCmd.AddCommand(restoreSnapshotCmd)
+	snapshotCmd.AddCommand(deleteSnapshotCmd)
+	RootCmd.AddCommand(snapshotCmd)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/snapshot"
+)
+
+// listSnapshotCmd represents the snapshot list command
+var listSnapshotCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the snapshots of the cluster.",
+	Long:  "Lists the snapshots of the current profile, along with when they were taken and their size.",
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := cfg.GetMachineName()
+		snaps, err := snapshot.List(profile)
+		if err != nil {
+			exit.WithError("Error listing snapshots", err)
+		}
+		if len(snaps) == 0 {
+			console.OutStyle("meh", "%q has no snapshots", profile)
+			return
+		}
+
+		var data [][]string
+		for _, s := range snaps {
+			data = append(data, []string{s.Name, s.Created.Format(time.RFC3339), units.HumanSize(float64(s.Size))})
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Created", "Size"})
+		table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+		table.SetCenterSeparator("|")
+		table.AppendBulk(data)
+		table.Render()
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(listSnapshotCmd)
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -25,6 +24,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +43,7 @@ import (
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
 	cmdutil "k8s.io/minikube/cmd/util"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/kubeadm"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	cfg "k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/console"
@@ -92,6 +93,9 @@ const (
 	embedCerts            = "embed-certs"
 	noVTXCheck            = "no-vtx-check"
 	downloadOnly          = "download-only"
+	configPatch           = "config-patch"
+	configPatchType       = "config-patch-type"
+	dryRun                = "dry-run"
 )
 
 var (
@@ -147,6 +151,9 @@ func init() {
 		`A set of key=value pairs that describe configuration that may be passed to different components.
 		The key should be '.' separated, and the first part before the dot is the component to apply the configuration to.
 		Valid components are: kubelet, kubeadm, apiserver, controller-manager, etcd, proxy, scheduler.`)
+	startCmd.Flags().String(configPatch, "", "A YAML file patching the generated kubeadm configuration, including the kubelet configuration. Each document patches the configuration of the same kind. It is kept in the profile: pass an empty value to remove it.")
+	startCmd.Flags().String(configPatchType, kubeadm.PatchTypeStrategic, "How --config-patch is merged: strategic (strategic merge patch) or merge (JSON merge patch)")
+	startCmd.Flags().Bool(dryRun, false, "Print the kubeadm and kubelet configuration, with --config-patch applied, without starting anything")
 	startCmd.Flags().String(uuid, "", "Provide VM UUID to restore MAC address (only supported with Hyperkit driver).")
 	startCmd.Flags().String(vpnkitSock, "", "Location of the VPNKit socket used for networking. If empty, disables Hyperkit VPNKitSock, if 'auto' uses Docker for Mac VPNKit connection, otherwise uses the specified VSock.")
 	startCmd.Flags().StringSlice(vsockPorts, []string{}, "List of guest VSock ports that should be exposed as sockets on the host (Only supported on with hyperkit now).")
@@ -180,6 +187,24 @@ func runStart(cmd *cobra.Command, args []string) {
 	if err != nil {
 		exit.WithError("Failed to generate config", err)
 	}
+	if oldConfig != nil {
+		keepProfileState(&config, oldConfig)
+		if !cmd.Flags().Changed(configPatch) {
+			config.KubernetesConfig.ConfigPatch = oldConfig.KubernetesConfig.ConfigPatch
+			if !cmd.Flags().Changed(configPatchType) {
+				config.KubernetesConfig.ConfigPatchType = oldConfig.KubernetesConfig.ConfigPatchType
+			}
+		}
+	}
+	validateConfigPatch(config.KubernetesConfig)
+
+	if viper.GetBool(dryRun) {
+		if oldConfig != nil {
+			config.KubernetesConfig.NodeIP = oldConfig.KubernetesConfig.NodeIP
+		}
+		renderConfig(config.KubernetesConfig)
+		return
+	}
 
 	// For non-"none", the ISO is required to boot, so block until it is downloaded
 	if viper.GetString(vmDriver) != constants.DriverNone {
@@ -241,10 +266,15 @@ func runStart(cmd *cobra.Command, args []string) {
 
 	// The kube config must be update must come before bootstrapping, otherwise health checks may use a stale IP
 	kubeconfig := updateKubeConfig(host, &config)
-	bootstrapCluster(bs, cr, runner, config.KubernetesConfig, preexisting, isUpgrade)
+	if preexisting && isUpgrade {
+		upgradeCluster(bs, cr, runner, config, *oldConfig)
+	} else {
+		bootstrapCluster(bs, cr, runner, config.KubernetesConfig, preexisting, isUpgrade)
+	}
 
 	apiserverPort := config.KubernetesConfig.NodePort
 	validateCluster(bs, cr, runner, ip, apiserverPort)
+	startNodes(m, bs, &config)
 	configureMounts()
 	if err = LoadCachedImagesInConfigFile(); err != nil {
 		console.Failure("Unable to load cached images from config file.")
@@ -363,6 +393,14 @@ func waitCacheImages(g *errgroup.Group) {
 	}
 }
 
+// keepProfileState copies the state that is managed by other commands from the previous profile config
+func keepProfileState(config *cfg.Config, oldConfig *cfg.Config) {
+	config.Nodes = oldConfig.Nodes
+	config.CachedImages = oldConfig.CachedImages
+	config.KubernetesConfig.AddonValues = oldConfig.KubernetesConfig.AddonValues
+	config.KubernetesConfig.AddonImages = oldConfig.KubernetesConfig.AddonImages
+}
+
 // generateConfig generates cfg.Config based on flags and supplied arguments
 func generateConfig(cmd *cobra.Command, k8sVersion string) (cfg.Config, error) {
 	r, err := cruntime.New(cruntime.Config{Type: viper.GetString(containerRuntime)})
@@ -415,6 +453,15 @@ func generateConfig(cmd *cobra.Command, k8sVersion string) (cfg.Config, error) {
 		console.OutStyle("success", "using image repository %s", repository)
 	}
 
+	var patch string
+	if p := viper.GetString(configPatch); p != "" {
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			exit.WithCode(exit.NoInput, "Unable to read the config patch: %v", err)
+		}
+		patch = string(data)
+	}
+
 	cfg := cfg.Config{
 		MachineConfig: cfg.MachineConfig{
 			MinikubeISO:         viper.GetString(isoURL),
@@ -459,6 +506,8 @@ func generateConfig(cmd *cobra.Command, k8sVersion string) (cfg.Config, error) {
 			ExtraOptions:           extraOptions,
 			ShouldLoadCachedImages: viper.GetBool(cacheImages),
 			EnableDefaultCNI:       selectedEnableDefaultCNI,
+			ConfigPatch:            patch,
+			ConfigPatchType:        viper.GetString(configPatchType),
 		},
 	}
 	return cfg, nil
@@ -536,6 +585,42 @@ func validateNetwork(h *host.Host) string {
 	return ip
 }
 
+// validateConfigPatch ensures that the config patch can be applied by the bootstrapper
+func validateConfigPatch(kc cfg.KubernetesConfig) {
+	if kc.ConfigPatch == "" {
+		return
+	}
+	if bs := viper.GetString(cmdcfg.Bootstrapper); bs != bootstrapper.BootstrapperTypeKubeadm {
+		exit.Usage("Sorry, --%s is only supported by the %s bootstrapper, not %s", configPatch, bootstrapper.BootstrapperTypeKubeadm, bs)
+	}
+	if err := kubeadm.ValidateConfigPatch(kc.ConfigPatch, kc.ConfigPatchType); err != nil {
+		exit.WithCode(exit.Data, "Invalid config patch: %v", err)
+	}
+}
+
+// renderConfig prints the configuration files which the bootstrapper would copy
+func renderConfig(kc cfg.KubernetesConfig) {
+	if bs := viper.GetString(cmdcfg.Bootstrapper); bs != bootstrapper.BootstrapperTypeKubeadm {
+		exit.Usage("Sorry, --%s is only supported by the %s bootstrapper, not %s", dryRun, bootstrapper.BootstrapperTypeKubeadm, bs)
+	}
+	if kc.NodeIP == "" {
+		// The address of the VM is only known once it started
+		kc.NodeIP = "<node-ip>"
+	}
+	files, err := kubeadm.RenderConfig(kc)
+	if err != nil {
+		exit.WithError("Failed to render config", err)
+	}
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		console.Out("# %s\n%s\n", p, strings.TrimSpace(files[p]))
+	}
+}
+
 // validateKubernetesVersions ensures that the requested version is reasonable
 func validateKubernetesVersions(old *cfg.Config) (string, bool) {
 	nv := viper.GetString(kubernetesVersion)
@@ -666,6 +751,31 @@ func bootstrapCluster(bs bootstrapper.Bootstrapper, r cruntime.Manager, runner b
 	}
 }
 
+// upgradeCluster upgrades the running cluster to the Kubernetes version of the config. If the upgrade fails,
+// the profile config is rolled back, so that the next start restores the previous version.
+func upgradeCluster(bs bootstrapper.Bootstrapper, r cruntime.Manager, runner bootstrapper.CommandRunner, c cfg.Config, old cfg.Config) {
+	bsName := viper.GetString(cmdcfg.Bootstrapper)
+	kc := c.KubernetesConfig
+
+	// Pull the images beforehand, so that the control plane is down for as little time as possible
+	console.OutStyle("pulling", "Pulling images ...")
+	if err := bs.PullImages(kc); err != nil {
+		console.OutStyle("failure", "Unable to pull images, which may be OK: %v", err)
+	}
+
+	console.OutStyle("restarting", "Upgrading Kubernetes from %s to %s using %s ... ", old.KubernetesConfig.KubernetesVersion, kc.KubernetesVersion, bsName)
+	if err := bs.UpgradeCluster(kc); err != nil {
+		// The VM keeps its address
+		old.KubernetesConfig.NodeIP = kc.NodeIP
+		if serr := saveConfig(old); serr != nil {
+			glog.Errorf("Failed to roll back the config: %v", serr)
+		} else {
+			console.ErrStyle("conflict", "Rolled the profile back to Kubernetes %s. To restore it, run: minikube start --kubernetes-version=%s", old.KubernetesConfig.KubernetesVersion, old.KubernetesConfig.KubernetesVersion)
+		}
+		exit.WithLogEntries("Error upgrading cluster", err, logs.FindProblems(r, bs, runner))
+	}
+}
+
 // validateCluster validates that the cluster is well-configured and healthy
 func validateCluster(bs bootstrapper.Bootstrapper, r cruntime.Manager, runner bootstrapper.CommandRunner, ip string, apiserverPort int) {
 	k8sStat := func() (err error) {
@@ -694,6 +804,20 @@ func validateCluster(bs bootstrapper.Bootstrapper, r cruntime.Manager, runner bo
 	console.OutLn("")
 }
 
+// startNodes restarts the worker nodes of the cluster, and rejoins them using the current configuration
+func startNodes(api libmachine.API, bs bootstrapper.Bootstrapper, c *cfg.Config) {
+	for i := range c.Nodes {
+		if err := startNode(api, bs, c, &c.Nodes[i]); err != nil {
+			exit.WithError(fmt.Sprintf("Failed to start node %q", c.Nodes[i].Name), err)
+		}
+	}
+	if len(c.Nodes) > 0 {
+		if err := saveConfig(*c); err != nil {
+			exit.WithError("Failed to save config", err)
+		}
+	}
+}
+
 // configureMounts configures any requested filesystem mounts
 func configureMounts() {
 	if !viper.GetBool(createMount) {
@@ -722,44 +846,5 @@ func configureMounts() {
 
 // saveConfig saves profile cluster configuration in $MINIKUBE_HOME/profiles/<profilename>/config.json
 func saveConfig(clusterConfig cfg.Config) error {
-	data, err := json.MarshalIndent(clusterConfig, "", "    ")
-	if err != nil {
-		return err
-	}
-	glog.Infof("Saving config:\n%s", data)
-	path := constants.GetProfileFile(viper.GetString(cfg.MachineProfile))
-	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
-		return err
-	}
-
-	// If no config file exists, don't worry about swapping paths
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		if err := ioutil.WriteFile(path, data, 0600); err != nil {
-			return err
-		}
-		return nil
-	}
-
-	tf, err := ioutil.TempFile(filepath.Dir(path), "config.json.tmp")
-	if err != nil {
-		return err
-	}
-	defer os.Remove(tf.Name())
-
-	if err = ioutil.WriteFile(tf.Name(), data, 0600); err != nil {
-		return err
-	}
-
-	if err = tf.Close(); err != nil {
-		return err
-	}
-
-	if err = os.Remove(path); err != nil {
-		return err
-	}
-
-	if err = os.Rename(tf.Name(), path); err != nil {
-		return err
-	}
-	return nil
+	return cfg.SaveProfile(viper.GetString(cfg.MachineProfile), &clusterConfig)
 }
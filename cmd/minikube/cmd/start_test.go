@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func TestRestartKeepsCachedImages(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "minikube")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer os.Setenv(constants.MinikubeHome, os.Getenv(constants.MinikubeHome))
+	os.Setenv(constants.MinikubeHome, tempDir)
+	defer viper.Set(cfg.MachineProfile, viper.GetString(cfg.MachineProfile))
+	viper.Set(cfg.MachineProfile, "restart")
+
+	images := []string{"busybox:latest", "k8s.gcr.io/pause:3.1"}
+	if err := saveConfig(cfg.Config{CachedImages: images}); err != nil {
+		t.Fatalf("saveConfig: %v", err)
+	}
+
+	oldConfig, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	config := cfg.Config{}
+	keepProfileState(&config, oldConfig)
+	if err := saveConfig(config); err != nil {
+		t.Fatalf("saveConfig: %v", err)
+	}
+
+	restarted, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(restarted.CachedImages, images) {
+		t.Errorf("CachedImages after restart = %v, want %v", restarted.CachedImages, images)
+	}
+}
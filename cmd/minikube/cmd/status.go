@@ -17,11 +17,16 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"text/template"
 
+	"github.com/docker/machine/libmachine"
 	"github.com/docker/machine/libmachine/state"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
@@ -35,13 +40,26 @@ import (
 )
 
 var statusFormat string
+var statusOutput string
+
+// KubeconfigStatus represents whether kubectl is configured to use the cluster
+type KubeconfigStatus struct {
+	Configured bool
+	Endpoint   string `json:",omitempty"`
+}
 
 // Status represents the status
 type Status struct {
-	Host       string
-	Kubelet    string
-	APIServer  string
-	Kubeconfig string
+	Name              string
+	Host              string
+	Kubelet           string
+	APIServer         string
+	Kubeconfig        string           `json:"-"`
+	KubeconfigStatus  KubeconfigStatus `json:"Kubeconfig"`
+	IP                string           `json:",omitempty"`
+	Port              int              `json:",omitempty"`
+	ContainerRuntime  string           `json:",omitempty"`
+	KubernetesVersion string           `json:",omitempty"`
 }
 
 const (
@@ -58,88 +76,128 @@ var statusCmd = &cobra.Command{
 	Exit status contains the status of minikube's VM, cluster and kubernetes encoded on it's bits in this order from right to left.
 	Eg: 7 meaning: 1 (for minikube NOK) + 2 (for cluster NOK) + 4 (for kubernetes NOK)`,
 	Run: func(cmd *cobra.Command, args []string) {
-		var returnCode = 0
+		if statusOutput != "text" && statusOutput != "json" {
+			exit.Usage("invalid output format: %s. Valid values: 'text', 'json'", statusOutput)
+		}
 		api, err := machine.NewAPIClient()
 		if err != nil {
 			exit.WithCode(exit.Unavailable, "Error getting client: %v", err)
 		}
 		defer api.Close()
 
-		hostSt, err := cluster.GetHostStatus(api)
-		if err != nil {
-			exit.WithError("Error getting host status", err)
-		}
-
-		kubeletSt := state.None.String()
-		kubeconfigSt := state.None.String()
-		apiserverSt := state.None.String()
-
-		if hostSt == state.Running.String() {
-			clusterBootstrapper, err := GetClusterBootstrapper(api, viper.GetString(cmdcfg.Bootstrapper))
-			if err != nil {
-				exit.WithError("Error getting bootstrapper", err)
-			}
-			kubeletSt, err = clusterBootstrapper.GetKubeletStatus()
-			if err != nil {
-				glog.Warningf("kubelet err: %v", err)
-				returnCode |= clusterNotRunningStatusFlag
-			} else if kubeletSt != state.Running.String() {
-				returnCode |= clusterNotRunningStatusFlag
-			}
-
-			ip, err := cluster.GetHostDriverIP(api, config.GetMachineName())
-			if err != nil {
-				glog.Errorln("Error host driver ip status:", err)
-			}
-
-			apiserverPort, err := pkgutil.GetPortFromKubeConfig(util.GetKubeConfigPath(), config.GetMachineName())
-			if err != nil {
-				// Fallback to presuming default apiserver port
-				apiserverPort = pkgutil.APIServerPort
-			}
-
-			apiserverSt, err = clusterBootstrapper.GetAPIServerStatus(ip, apiserverPort)
-			if err != nil {
-				glog.Errorln("Error apiserver status:", err)
-			} else if apiserverSt != state.Running.String() {
-				returnCode |= clusterNotRunningStatusFlag
-			}
-
-			ks, err := pkgutil.GetKubeConfigStatus(ip, util.GetKubeConfigPath(), config.GetMachineName())
-			if err != nil {
-				glog.Errorln("Error kubeconfig status:", err)
-			}
-			if ks {
-				kubeconfigSt = "Correctly Configured: pointing to minikube-vm at " + ip.String()
-			} else {
-				kubeconfigSt = "Misconfigured: pointing to stale minikube-vm." +
-					"\nTo fix the kubectl context, run minikube update-context"
-				returnCode |= k8sNotRunningStatusFlag
-			}
-		} else {
-			returnCode |= minikubeNotRunningStatusFlag
-		}
-
-		status := Status{
-			Host:       hostSt,
-			Kubelet:    kubeletSt,
-			APIServer:  apiserverSt,
-			Kubeconfig: kubeconfigSt,
+		status, returnCode := getStatus(api)
+		switch statusOutput {
+		case "json":
+			err = statusJSON(os.Stdout, status)
+		default:
+			err = statusText(os.Stdout, status)
 		}
-		tmpl, err := template.New("status").Parse(statusFormat)
 		if err != nil {
-			exit.WithError("Error creating status template", err)
-		}
-		err = tmpl.Execute(os.Stdout, status)
-		if err != nil {
-			exit.WithError("Error executing status template", err)
+			exit.WithError("Error printing status", err)
 		}
 
 		os.Exit(returnCode)
 	},
 }
 
+// getStatus returns the status of the cluster, along with the exit code describing it
+func getStatus(api libmachine.API) (Status, int) {
+	var returnCode = 0
+	status := Status{
+		Name:       config.GetMachineName(),
+		Kubelet:    state.None.String(),
+		APIServer:  state.None.String(),
+		Kubeconfig: state.None.String(),
+	}
+
+	hostSt, err := cluster.GetHostStatus(api, config.GetMachineName())
+	if err != nil {
+		exit.WithError("Error getting host status", err)
+	}
+	status.Host = hostSt
+
+	if cc, err := config.Load(); err == nil {
+		status.ContainerRuntime = cc.KubernetesConfig.ContainerRuntime
+		status.KubernetesVersion = cc.KubernetesConfig.KubernetesVersion
+	} else if !os.IsNotExist(err) {
+		glog.Warningf("Error loading profile config: %v", err)
+	}
+
+	if hostSt != state.Running.String() {
+		return status, returnCode | minikubeNotRunningStatusFlag
+	}
+
+	clusterBootstrapper, err := GetClusterBootstrapper(api, viper.GetString(cmdcfg.Bootstrapper))
+	if err != nil {
+		exit.WithError("Error getting bootstrapper", err)
+	}
+	status.Kubelet, err = clusterBootstrapper.GetKubeletStatus()
+	if err != nil {
+		glog.Warningf("kubelet err: %v", err)
+		returnCode |= clusterNotRunningStatusFlag
+	} else if status.Kubelet != state.Running.String() {
+		returnCode |= clusterNotRunningStatusFlag
+	}
+
+	ip, err := cluster.GetHostDriverIP(api, config.GetMachineName())
+	if err != nil {
+		glog.Errorln("Error host driver ip status:", err)
+	}
+	if ip != nil {
+		status.IP = ip.String()
+	}
+
+	apiserverPort, err := pkgutil.GetPortFromKubeConfig(util.GetKubeConfigPath(), config.GetMachineName())
+	if err != nil {
+		// Fallback to presuming default apiserver port
+		apiserverPort = pkgutil.APIServerPort
+	}
+	status.Port = apiserverPort
+
+	status.APIServer, err = clusterBootstrapper.GetAPIServerStatus(ip, apiserverPort)
+	if err != nil {
+		glog.Errorln("Error apiserver status:", err)
+	} else if status.APIServer != state.Running.String() {
+		returnCode |= clusterNotRunningStatusFlag
+	}
+
+	ks, err := pkgutil.GetKubeConfigStatus(ip, util.GetKubeConfigPath(), config.GetMachineName())
+	if err != nil {
+		glog.Errorln("Error kubeconfig status:", err)
+	}
+	status.KubeconfigStatus.Configured = ks
+	if endpoint, err := pkgutil.GetKubeConfigEndpoint(util.GetKubeConfigPath(), config.GetMachineName()); err == nil {
+		status.KubeconfigStatus.Endpoint = endpoint
+	}
+	if ks {
+		status.Kubeconfig = "Correctly Configured: pointing to minikube-vm at " + ip.String()
+	} else {
+		status.Kubeconfig = "Misconfigured: pointing to stale minikube-vm." +
+			"\nTo fix the kubectl context, run minikube update-context"
+		returnCode |= k8sNotRunningStatusFlag
+	}
+	return status, returnCode
+}
+
+func statusText(w io.Writer, st Status) error {
+	tmpl, err := template.New("status").Parse(statusFormat)
+	if err != nil {
+		return errors.Wrap(err, "creating status template")
+	}
+	return tmpl.Execute(w, st)
+}
+
+func statusJSON(w io.Writer, st Status) error {
+	js, err := json.Marshal(st)
+	if err != nil {
+		return errors.Wrap(err, "encoding status")
+	}
+	_, err = fmt.Fprintln(w, string(js))
+	return err
+}
+
 func init() {
+	statusCmd.Flags().StringVarP(&statusOutput, "output", "o", "text", "The output format. One of 'text', 'json'. With 'text', the --format template is used")
 	statusCmd.Flags().StringVar(&statusFormat, "format", constants.DefaultStatusFormat,
 		`Go template format string for the status output.  The format for Go templates can be found here: https://golang.org/pkg/text/template/
 For the list accessible variables for the template, see the struct values here: https://godoc.org/k8s.io/minikube/cmd/minikube/cmd#Status`)
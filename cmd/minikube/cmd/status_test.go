@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+var testStatus = Status{
+	Name:              "minikube",
+	Host:              "Running",
+	Kubelet:           "Running",
+	APIServer:         "Running",
+	Kubeconfig:        "Correctly Configured: pointing to minikube-vm at 192.168.99.100",
+	KubeconfigStatus:  KubeconfigStatus{Configured: true, Endpoint: "https://192.168.99.100:8443"},
+	IP:                "192.168.99.100",
+	Port:              8443,
+	ContainerRuntime:  "docker",
+	KubernetesVersion: "v1.14.1",
+}
+
+func TestStatusText(t *testing.T) {
+	statusFormat = constants.DefaultStatusFormat
+	var b bytes.Buffer
+	if err := statusText(&b, testStatus); err != nil {
+		t.Fatalf("statusText: %v", err)
+	}
+	expected := `host: Running
+kubelet: Running
+apiserver: Running
+kubectl: Correctly Configured: pointing to minikube-vm at 192.168.99.100
+`
+	if b.String() != expected {
+		t.Errorf("statusText() = %q, expected %q", b.String(), expected)
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := statusJSON(&b, testStatus); err != nil {
+		t.Fatalf("statusJSON: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", b.String(), err)
+	}
+	kc, ok := got["Kubeconfig"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected Kubeconfig to be an object, got %v", got["Kubeconfig"])
+	}
+	if kc["Configured"] != true || kc["Endpoint"] != "https://192.168.99.100:8443" {
+		t.Errorf("unexpected Kubeconfig: %v", kc)
+	}
+	for k, v := range map[string]interface{}{
+		"Host":              "Running",
+		"APIServer":         "Running",
+		"IP":                "192.168.99.100",
+		"Port":              float64(8443),
+		"KubernetesVersion": "v1.14.1",
+	} {
+		if got[k] != v {
+			t.Errorf("%s = %v, expected %v", k, got[k], v)
+		}
+	}
+}
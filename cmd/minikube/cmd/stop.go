@@ -46,10 +46,27 @@ itself, leaving all files intact. The cluster can be started again with the "sta
 		}
 		defer api.Close()
 
+		// Stop worker nodes before the control plane, so that they don't try to reschedule pods
+		if cc, err := pkg_config.Load(); err == nil {
+			for _, n := range cc.Nodes {
+				name := n.MachineName(profile)
+				stopNode := func() error {
+					err := cluster.StopHost(api, name)
+					if _, ok := errors.Cause(err).(mcnerror.ErrHostDoesNotExist); ok {
+						return nil
+					}
+					return err
+				}
+				if err := pkgutil.RetryAfter(5, stopNode, 1*time.Second); err != nil {
+					exit.WithError("Unable to stop node VM", err)
+				}
+			}
+		}
+
 		nonexistent := false
 
 		stop := func() (err error) {
-			err = cluster.StopHost(api)
+			err = cluster.StopHost(api, profile)
 			switch err := errors.Cause(err).(type) {
 			case mcnerror.ErrHostDoesNotExist:
 				console.OutStyle("meh", "%q VM does not exist, nothing to stop", profile)
@@ -18,20 +18,35 @@ package cmd
 
 import (
 	"context"
+	"net"
 	"os"
 	"os/signal"
 	"time"
 
+	"github.com/docker/machine/libmachine"
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/ingressdns"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/service"
 	"k8s.io/minikube/pkg/minikube/tunnel"
 )
 
-var cleanup bool
+var (
+	cleanup     bool
+	userspace   bool
+	bindAddress string
+	lbIPPool    string
+	reverse     string
+	reverseSvc  string
+	ingressDNS  bool
+	dnsAddress  string
+	tunnelOut   string
+)
 
 // tunnelCmd represents the tunnel command
 var tunnelCmd = &cobra.Command{
@@ -52,6 +67,31 @@ var tunnelCmd = &cobra.Command{
 			return
 		}
 
+		switch tunnelOut {
+		case "json":
+			manager.ReportJSON(os.Stdout)
+		case "text":
+		default:
+			exit.Usage("invalid output format: %s. Valid values: 'text', 'json'", tunnelOut)
+		}
+		if (reverse == "") != (reverseSvc == "") {
+			exit.Usage("--reverse and --service must be used together")
+		}
+		if reverse != "" && (userspace || lbIPPool != "") {
+			exit.Usage("--reverse can not be used with --userspace or --lb-ip-pool")
+		}
+
+		var pool *net.IPNet
+		if lbIPPool != "" {
+			if userspace {
+				exit.Usage("--lb-ip-pool can not be used with --userspace")
+			}
+			var err error
+			if _, pool, err = net.ParseCIDR(lbIPPool); err != nil {
+				exit.Usage("invalid LoadBalancer IP pool %q: %v", lbIPPool, err)
+			}
+		}
+
 		glog.Infof("Creating docker machine client...")
 		api, err := machine.NewAPIClient()
 		if err != nil {
@@ -76,7 +116,19 @@ var tunnelCmd = &cobra.Command{
 			cancel()
 		}()
 
-		done, err := manager.StartTunnel(ctx, config.GetMachineName(), api, config.DefaultLoader, clientset.CoreV1())
+		if ingressDNS {
+			startIngressDNS(ctx, api)
+		}
+
+		var done chan bool
+		switch {
+		case reverse != "":
+			done, err = manager.StartReverseTunnel(ctx, config.GetMachineName(), api, config.DefaultLoader, clientset.CoreV1(), reverse, reverseSvc)
+		case userspace:
+			done, err = manager.StartUserspaceTunnel(ctx, config.GetMachineName(), api, config.DefaultLoader, clientset.CoreV1(), bindAddress)
+		default:
+			done, err = manager.StartTunnel(ctx, config.GetMachineName(), api, config.DefaultLoader, clientset.CoreV1(), pool)
+		}
 		if err != nil {
 			exit.WithError("error starting tunnel", err)
 		}
@@ -84,7 +136,41 @@ var tunnelCmd = &cobra.Command{
 	},
 }
 
+// startIngressDNS answers DNS queries for the hosts of ingresses with the IP of the cluster, until the context is done
+func startIngressDNS(ctx context.Context, api libmachine.API) {
+	//the ingresses are watched over long-lived requests, so the clientset of the tunnel status checks,
+	//which times out after a second, can not be used
+	clientset, err := service.K8s.GetClientset(0)
+	if err != nil {
+		exit.WithError("error creating clientset", err)
+	}
+	ip, err := cluster.GetHostDriverIP(api, config.GetMachineName())
+	if err != nil {
+		exit.WithError("Error getting cluster IP", err)
+	}
+	conn, err := net.ListenPacket("udp", dnsAddress)
+	if err != nil {
+		exit.WithError("Error listening for DNS queries", err)
+	}
+	server := ingressdns.NewServer()
+	go ingressdns.WatchIngresses(ctx, clientset.ExtensionsV1beta1(), ip, server)
+	go func() {
+		if err := server.Serve(ctx, conn); err != nil {
+			glog.Errorf("error answering DNS queries: %v", err)
+		}
+	}()
+	console.OutStyle("launch", "Answering DNS queries for ingress hosts with %s on %s", ip, conn.LocalAddr())
+}
+
 func init() {
 	tunnelCmd.Flags().BoolVarP(&cleanup, "cleanup", "c", false, "call with cleanup=true to remove old tunnels")
+	tunnelCmd.Flags().BoolVar(&userspace, "userspace", false, "forward the ports of LoadBalancer services from host listeners over SSH, instead of adding a route. Does not require root privileges")
+	tunnelCmd.Flags().StringVar(&lbIPPool, "lb-ip-pool", "", "a CIDR to allocate the LoadBalancer IPs of services from, such as 172.30.0.0/24. By default, services get their ClusterIP")
+	tunnelCmd.Flags().StringVar(&reverse, "reverse", "", "a host:port to expose inside the cluster as the service given by --service, instead of exposing LoadBalancer services on the host")
+	tunnelCmd.Flags().StringVar(&reverseSvc, "service", "", "the service to create for --reverse, as namespace/name[:port]. The port defaults to the host port")
+	tunnelCmd.Flags().StringVarP(&tunnelOut, "output", "o", "text", "the format to report the status of the tunnel in on every check: 'text', or 'json' for a line of JSON")
+	tunnelCmd.Flags().BoolVar(&ingressDNS, "ingress-dns", false, "answer DNS queries for the hosts of ingresses with the IP of the cluster")
+	tunnelCmd.Flags().StringVar(&dnsAddress, "ingress-dns-address", "127.0.0.1:15353", "the UDP address to answer DNS queries on, with --ingress-dns")
+	tunnelCmd.Flags().StringVar(&bindAddress, "bind-address", "127.0.0.1", "the address to open the host listeners on, when forwarding ports in userspace")
 	RootCmd.AddCommand(tunnelCmd)
 }
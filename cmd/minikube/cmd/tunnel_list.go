@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/tunnel"
+)
+
+var tunnelListOutput string
+
+// tunnelListCmd represents the tunnel list command
+var tunnelListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the tunnels in the tunnel registry.",
+	Long:  "Lists the routes created by tunnels, with their profile, process and patched services, and whether the process is still running.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			exit.Usage("usage: minikube tunnel list")
+		}
+		tunnels, err := tunnel.NewManager().ListTunnels()
+		if err != nil {
+			exit.WithError("Failed to list tunnels", err)
+		}
+		switch tunnelListOutput {
+		case "json":
+			printTunnelsJSON(tunnels)
+		case "table":
+			printTunnelsTable(tunnels)
+		default:
+			exit.Usage("invalid output format: %s. Valid values: 'table', 'json'", tunnelListOutput)
+		}
+	},
+}
+
+func printTunnelsTable(tunnels []tunnel.Info) {
+	if len(tunnels) == 0 {
+		console.OutStyle("meh", "No tunnels are registered")
+		return
+	}
+	var data [][]string
+	stale := false
+	for _, t := range tunnels {
+		status := "Running"
+		if !t.Running {
+			status = "Not Running"
+			stale = true
+		}
+		data = append(data, []string{t.MachineName, t.Route, strconv.Itoa(t.Pid), status, strings.Join(t.PatchedServices, ", ")})
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Profile", "Route", "PID", "Status", "Services"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(data)
+	table.Render()
+
+	if stale {
+		console.ErrStyle("tip", "To remove the routes of tunnels which are not running: minikube tunnel --cleanup")
+	}
+}
+
+func printTunnelsJSON(tunnels []tunnel.Info) {
+	for i := range tunnels {
+		if tunnels[i].PatchedServices == nil {
+			tunnels[i].PatchedServices = []string{}
+		}
+	}
+	b, err := json.Marshal(tunnels)
+	if err != nil {
+		exit.WithError("Failed to marshal tunnels", err)
+	}
+	console.OutLn("%s", b)
+}
+
+func init() {
+	tunnelListCmd.Flags().StringVarP(&tunnelListOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	tunnelCmd.AddCommand(tunnelListCmd)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	cfg "k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+// unpauseCmd represents the unpause command
+var unpauseCmd = &cobra.Command{
+	Use:   "unpause",
+	Short: "Resumes the Kubernetes containers of a paused cluster",
+	Long:  `Resumes the Kubernetes containers paused by the "pause" command, and starts kubelet again.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		api, err := machine.NewAPIClient()
+		if err != nil {
+			exit.WithError("Error getting client", err)
+		}
+		defer api.Close()
+		cluster.EnsureMinikubeRunningOrExit(api, 0)
+
+		count := 0
+		err = forEachClusterMachine(api, func(name string, cr cruntime.Manager, r bootstrapper.CommandRunner) error {
+			ids, err := cluster.Unpause(cr, r)
+			count += len(ids)
+			return err
+		})
+		if err != nil {
+			exit.WithError("Unpause", err)
+		}
+		console.OutStyle("unpaused", "Unpaused %d containers in %q", count, cfg.GetMachineName())
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(unpauseCmd)
+}
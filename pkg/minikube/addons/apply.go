@@ -0,0 +1,174 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/util"
+)
+
+// reconcileSelector selects the resources which the addon manager reconciles, and prunes once their
+// manifest is removed
+const reconcileSelector = "addonmanager.kubernetes.io/mode=Reconcile"
+
+// kubectlPath returns where the cached kubectl of a Kubernetes version is copied to in the node
+func kubectlPath(version string) string {
+	return path.Join(constants.GetGuestBinariesDir(version), "kubectl")
+}
+
+// resultRe matches the lines kubectl prints for each resource, e.g. `deployment.apps/registry created`,
+// or `replicationcontroller "registry" deleted` for older versions
+var resultRe = regexp.MustCompile(`^(\S+?)(?:/(\S+)| "([^"]+)") (created|configured|unchanged|pruned|deleted)$`)
+
+// Result is what happened to a resource of an addon
+type Result struct {
+	Resource string
+	Action   string
+}
+
+// Results are the resources kubectl reported on, and the lines it printed which are not about a resource,
+// such as errors
+type Results struct {
+	Resources []Result
+	Messages  []string
+}
+
+// kubectl returns the command running the kubectl of the node, with the kubeconfig of the node
+func kubectl(version string, args ...string) string {
+	return fmt.Sprintf("sudo %s --kubeconfig=%s %s", kubectlPath(version), util.DefaultKubeConfigPath, strings.Join(args, " "))
+}
+
+// InstallKubectl copies the kubectl of a Kubernetes version to the node, unless it is there already
+func InstallKubectl(cr bootstrapper.CommandRunner, version string) error {
+	if err := cr.Run(fmt.Sprintf("sudo test -x %s", kubectlPath(version))); err == nil {
+		return nil
+	}
+	p, err := machine.CacheBinary("kubectl", version, "linux", runtime.GOARCH)
+	if err != nil {
+		return errors.Wrap(err, "downloading kubectl")
+	}
+	if err := machine.CopyBinaryToDir(cr, "kubectl", p, constants.GetGuestBinariesDir(version)); err != nil {
+		return errors.Wrap(err, "copying kubectl")
+	}
+	return nil
+}
+
+// manifests returns the paths in the node of the manifests of the files, which are applied by kubectl
+func manifests(files []assets.CopyableFile) []string {
+	var paths []string
+	for _, f := range files {
+		ext := path.Ext(f.GetTargetName())
+		if f.GetTargetDir() == constants.AddonsPath && (ext == ".yaml" || ext == ".yml" || ext == ".json") {
+			paths = append(paths, path.Join(f.GetTargetDir(), f.GetTargetName()))
+		}
+	}
+	return paths
+}
+
+// parseResults parses the output of kubectl
+func parseResults(out string, r *Results) {
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := resultRe.FindStringSubmatch(line); m != nil {
+			r.Resources = append(r.Resources, Result{Resource: m[1] + "/" + m[2] + m[3], Action: m[4]})
+			continue
+		}
+		r.Messages = append(r.Messages, line)
+	}
+}
+
+// run runs kubectl in the node, adding its output to the results
+func run(cr bootstrapper.CommandRunner, version string, r *Results, args ...string) error {
+	cmd := kubectl(version, args...)
+	out, err := cr.CombinedOutput(cmd)
+	glog.Infof("%s: %s", cmd, out)
+	parseResults(out, r)
+	return err
+}
+
+// Apply applies the manifests of an addon, which must have been copied to the node, then prunes the
+// resources of the manifests which were removed, like the addon manager does
+func Apply(cr bootstrapper.CommandRunner, version string, files []assets.CopyableFile) (*Results, error) {
+	r := &Results{}
+	paths := manifests(files)
+	if len(paths) == 0 {
+		return r, nil
+	}
+	args := []string{"apply"}
+	for _, p := range paths {
+		args = append(args, "-f", p)
+	}
+	if err := run(cr, version, r, args...); err != nil {
+		return r, errors.Wrap(err, "kubectl apply")
+	}
+	return r, prune(cr, version, r)
+}
+
+// Delete deletes the resources of the manifests of an addon, which must still be in the node
+func Delete(cr bootstrapper.CommandRunner, version string, files []assets.CopyableFile) (*Results, error) {
+	r := &Results{}
+	paths := manifests(files)
+	if len(paths) == 0 {
+		return r, nil
+	}
+	args := []string{"delete", "--ignore-not-found"}
+	for _, p := range paths {
+		args = append(args, "-f", p)
+	}
+	if err := run(cr, version, r, args...); err != nil {
+		return r, errors.Wrap(err, "kubectl delete")
+	}
+	return r, nil
+}
+
+// prune applies every manifest of the addons directory, pruning the reconciled resources which are no longer
+// in any of them, such as the ones an addon dropped after being configured
+func prune(cr bootstrapper.CommandRunner, version string, r *Results) error {
+	pruned := &Results{}
+	err := run(cr, version, pruned, "apply", "-f", constants.AddonsPath, "--recursive", "--prune", "-l", reconcileSelector)
+	// only the pruned resources are reported, the others were applied already
+	for _, res := range pruned.Resources {
+		if res.Action == "pruned" {
+			r.Resources = append(r.Resources, res)
+		}
+	}
+	if err != nil {
+		for _, m := range pruned.Messages {
+			// none of the manifests has resources to reconcile
+			if strings.Contains(m, "no objects passed to apply") {
+				return nil
+			}
+		}
+		r.Messages = append(r.Messages, pruned.Messages...)
+		return errors.Wrap(err, "kubectl apply --prune")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func registryFiles() []assets.CopyableFile {
+	return []assets.CopyableFile{
+		assets.NewMemoryAsset([]byte("kind: ReplicationController"), constants.AddonsPath, "registry-rc.yaml", "0640"),
+		assets.NewMemoryAsset([]byte("kind: Service"), constants.AddonsPath, "registry-svc.yaml", "0640"),
+		assets.NewMemoryAsset([]byte("[runsc_config]"), constants.GvisorFilesPath, "gvisor-config.toml", "0640"),
+	}
+}
+
+func TestApply(t *testing.T) {
+	cr := bootstrapper.NewFakeCommandRunner()
+	cr.SetCommandToOutput(map[string]string{
+		"sudo /var/lib/minikube/binaries/v1.14.0/kubectl --kubeconfig=/var/lib/minikube/kubeconfig apply -f /etc/kubernetes/addons/registry-rc.yaml -f /etc/kubernetes/addons/registry-svc.yaml":     "replicationcontroller/registry created\nservice/registry unchanged\n",
+		"sudo /var/lib/minikube/binaries/v1.14.0/kubectl --kubeconfig=/var/lib/minikube/kubeconfig apply -f /etc/kubernetes/addons --recursive --prune -l addonmanager.kubernetes.io/mode=Reconcile": "replicationcontroller/registry unchanged\nservice/registry unchanged\nservice/registry-proxy pruned\n",
+	})
+	results, err := Apply(cr, "v1.14.0", registryFiles())
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	expected := &Results{Resources: []Result{
+		{Resource: "replicationcontroller/registry", Action: "created"},
+		{Resource: "service/registry", Action: "unchanged"},
+		{Resource: "service/registry-proxy", Action: "pruned"},
+	}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %+v, got %+v", expected, results)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cr := bootstrapper.NewFakeCommandRunner()
+	cr.SetCommandToOutput(map[string]string{
+		"sudo /var/lib/minikube/binaries/v1.14.0/kubectl --kubeconfig=/var/lib/minikube/kubeconfig delete --ignore-not-found -f /etc/kubernetes/addons/registry-rc.yaml -f /etc/kubernetes/addons/registry-svc.yaml": "replicationcontroller \"registry\" deleted\nservice/registry deleted\nerror: the server is currently unable to handle the request\n",
+	})
+	results, err := Delete(cr, "v1.14.0", registryFiles())
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	expected := &Results{Resources: []Result{
+		{Resource: "replicationcontroller/registry", Action: "deleted"},
+		{Resource: "service/registry", Action: "deleted"},
+	}, Messages: []string{"error: the server is currently unable to handle the request"}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %+v, got %+v", expected, results)
+	}
+}
+
+func TestApplyWithoutManifests(t *testing.T) {
+	// no command is expected to run
+	cr := bootstrapper.NewFakeCommandRunner()
+	files := []assets.CopyableFile{assets.NewMemoryAsset([]byte("[runsc_config]"), constants.GvisorFilesPath, "gvisor-config.toml", "0640")}
+	if _, err := Apply(cr, "v1.14.0", files); err != nil {
+		t.Errorf("Apply: %v", err)
+	}
+	if _, err := Delete(cr, "v1.14.0", files); err != nil {
+		t.Errorf("Delete: %v", err)
+	}
+}
+
+func TestInstallKubectlInstalled(t *testing.T) {
+	// kubectl is not downloaded again once it is in the node
+	cr := bootstrapper.NewFakeCommandRunner()
+	cr.SetCommandToOutput(map[string]string{
+		"sudo test -x /var/lib/minikube/binaries/v1.14.0/kubectl": "",
+	})
+	if err := InstallKubectl(cr, "v1.14.0"); err != nil {
+		t.Errorf("InstallKubectl: %v", err)
+	}
+}
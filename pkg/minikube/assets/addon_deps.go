@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// withRequires declares the addons which must be enabled before the addon
+func (a *Addon) withRequires(names ...string) *Addon {
+	a.Requires = names
+	return a
+}
+
+// withConflicts declares the addons which cannot be enabled along with the addon
+func (a *Addon) withConflicts(names ...string) *Addon {
+	a.Conflicts = names
+	return a
+}
+
+// EnableOrder returns the addons to enable for an addon: its requirements, transitively,
+// each one after its own requirements, and the addon last
+func EnableOrder(name string) ([]string, error) {
+	var order []string
+	done := map[string]bool{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if done[name] {
+			return nil
+		}
+		for _, p := range path {
+			if p == name {
+				return fmt.Errorf("addons require each other: %s", strings.Join(append(path, name), " -> "))
+			}
+		}
+		addon, ok := Addons[name]
+		if !ok {
+			if len(path) == 0 {
+				return fmt.Errorf("unknown addon %s", name)
+			}
+			return fmt.Errorf("%s requires unknown addon %s", path[len(path)-1], name)
+		}
+		for _, r := range addon.Requires {
+			if err := visit(r, append(path, name)); err != nil {
+				return err
+			}
+		}
+		done[name] = true
+		order = append(order, name)
+		return nil
+	}
+	if err := visit(name, nil); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// ConflictsWith returns whether the addon cannot be enabled along with another one, as declared by either
+func (a *Addon) ConflictsWith(name string) bool {
+	for _, c := range a.Conflicts {
+		if c == name {
+			return true
+		}
+	}
+	if other, ok := Addons[name]; ok {
+		for _, c := range other.Conflicts {
+			if c == a.addonName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequiredBy returns the addons which require an addon directly
+func RequiredBy(name string) []string {
+	var names []string
+	for n, addon := range Addons {
+		for _, r := range addon.Requires {
+			if r == name {
+				names = append(names, n)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnableOrder(t *testing.T) {
+	defer func() {
+		delete(Addons, "test-a")
+		delete(Addons, "test-b")
+		delete(Addons, "test-c")
+	}()
+	Addons["test-a"] = NewAddon(nil, false, "test-a").withRequires("test-b", "nvidia-gpu-device-plugin")
+	Addons["test-b"] = NewAddon(nil, false, "test-b").withRequires("storage-provisioner")
+
+	tcs := []struct {
+		name     string
+		expected []string
+		err      string
+	}{
+		{name: "dashboard", expected: []string{"dashboard"}},
+		{name: "nvidia-gpu-device-plugin", expected: []string{"nvidia-driver-installer", "nvidia-gpu-device-plugin"}},
+		{name: "test-a", expected: []string{"storage-provisioner", "test-b", "nvidia-driver-installer", "nvidia-gpu-device-plugin", "test-a"}},
+		{name: "unknown", err: "unknown addon unknown"},
+	}
+	for _, tc := range tcs {
+		order, err := EnableOrder(tc.name)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%s: expected error %q, got %v", tc.name, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(order, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, order)
+		}
+	}
+
+	Addons["test-b"].withRequires("test-c")
+	Addons["test-c"] = NewAddon(nil, false, "test-c").withRequires("test-a")
+	if _, err := EnableOrder("test-a"); err == nil || !strings.Contains(err.Error(), "test-a -> test-b -> test-c -> test-a") {
+		t.Errorf("expected an error for the cycle, got %v", err)
+	}
+}
+
+func TestConflictsWith(t *testing.T) {
+	if !Addons["storage-provisioner-gluster"].ConflictsWith("default-storageclass") {
+		t.Errorf("expected storage-provisioner-gluster to conflict with default-storageclass")
+	}
+	if !Addons["default-storageclass"].ConflictsWith("storage-provisioner-gluster") {
+		t.Errorf("expected conflicts to be declared by either addon")
+	}
+	if Addons["efk"].ConflictsWith("default-storageclass") {
+		t.Errorf("expected efk not to conflict with default-storageclass")
+	}
+}
+
+func TestRequiredBy(t *testing.T) {
+	if got := RequiredBy("nvidia-driver-installer"); !reflect.DeepEqual(got, []string{"nvidia-gpu-device-plugin"}) {
+		t.Errorf("expected nvidia-driver-installer to be required by nvidia-gpu-device-plugin, got %v", got)
+	}
+	if got := RequiredBy("dashboard"); got != nil {
+		t.Errorf("expected dashboard to be required by no addon, got %v", got)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// imageRe matches the images of the containers of a manifest, optionally quoted
+var imageRe = regexp.MustCompile(`(?m)^(\s*(?:-\s+)?image:\s*)("?)([^\s"#]+)("?)`)
+
+// OverrideImage returns the image to use instead of an image of an addon: its full override if there is one,
+// otherwise the image in the override registry, if there is one
+func OverrideImage(image string, o config.AddonImages) string {
+	if ref, ok := o.Images[image]; ok {
+		return ref
+	}
+	if o.Registry == "" {
+		return image
+	}
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		glog.Warningf("not overriding the registry of %q: %v", image, err)
+		return image
+	}
+	sep := ":"
+	if _, ok := ref.(name.Digest); ok {
+		sep = "@"
+	}
+	return strings.TrimSuffix(o.Registry, "/") + "/" + ref.Context().RepositoryStr() + sep + ref.Identifier()
+}
+
+// manifestImages returns the images of a manifest
+func manifestImages(manifest []byte) []string {
+	var images []string
+	for _, m := range imageRe.FindAllSubmatch(manifest, -1) {
+		images = append(images, string(m[3]))
+	}
+	return images
+}
+
+// overrideManifestImages replaces the images of a manifest by their overrides
+func overrideManifestImages(manifest []byte, o config.AddonImages) []byte {
+	return imageRe.ReplaceAllFunc(manifest, func(line []byte) []byte {
+		m := imageRe.FindSubmatch(line)
+		return []byte(string(m[1]) + string(m[2]) + OverrideImage(string(m[3]), o) + string(m[4]))
+	})
+}
+
+// isManifest returns whether a file is a Kubernetes manifest, as opposed to e.g. the configuration of a daemon
+func isManifest(f CopyableFile) bool {
+	ext := path.Ext(strings.TrimSuffix(f.GetTargetName(), ".tmpl"))
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+// RenderAssets returns the assets of the addon for a cluster: the templates are evaluated with the values
+// of the addon, and the images of the manifests are overridden as configured for the addon
+func (a *Addon) RenderAssets(cfg config.KubernetesConfig) ([]CopyableFile, error) {
+	data := GenerateTemplateData(cfg, a.Values(cfg))
+	o, ok := cfg.AddonImages[a.addonName]
+	var files []CopyableFile
+	for _, asset := range a.Assets {
+		override := ok && isManifest(asset)
+		if !asset.IsTemplate() && !override {
+			files = append(files, asset)
+			continue
+		}
+		contents := asset.data
+		if asset.IsTemplate() {
+			evaluated, err := asset.Evaluate(data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "evaluate bundled addon %s asset", asset.GetAssetName())
+			}
+			contents = evaluated.data
+		}
+		if override {
+			contents = overrideManifestImages(contents, o)
+		}
+		files = append(files, NewMemoryAsset(contents, asset.GetTargetDir(), asset.GetTargetName(), asset.GetPermissions()))
+	}
+	return files, nil
+}
+
+// ImagesFor returns the images the addon runs in a cluster, with their overrides
+func (a *Addon) ImagesFor(cfg config.KubernetesConfig) ([]string, error) {
+	files, err := a.RenderAssets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	o := cfg.AddonImages[a.addonName]
+	seen := map[string]bool{}
+	for _, image := range a.Images {
+		seen[OverrideImage(image, o)] = true
+	}
+	for _, f := range files {
+		if !isManifest(f) {
+			continue
+		}
+		// the data is read directly, as reading the assets would consume them
+		var contents []byte
+		switch f := f.(type) {
+		case *BinAsset:
+			contents = f.data
+		case *MemoryAsset:
+			contents = f.data
+		}
+		for _, image := range manifestImages(contents) {
+			seen[image] = true
+		}
+	}
+	var images []string
+	for image := range seen {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// ValidateImages returns an error if any of the images to override is not an image of the addon
+func (a *Addon) ValidateImages(cfg config.KubernetesConfig, images map[string]string) error {
+	// the images to override are the original ones
+	cfg.AddonImages = nil
+	original, err := a.ImagesFor(cfg)
+	if err != nil {
+		return err
+	}
+	known := map[string]bool{}
+	for _, image := range original {
+		known[image] = true
+	}
+	for image := range images {
+		if !known[image] {
+			return fmt.Errorf("%q is not an image of addon %s, its images are: %s", image, a.addonName, strings.Join(original, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"io/ioutil"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestOverrideImage(t *testing.T) {
+	o := config.AddonImages{
+		Registry: "mirror.example.com/",
+		Images:   map[string]string{"nginx:1.15": "other.example.com/nginx:1.15.1"},
+	}
+	tcs := []struct {
+		image    string
+		o        config.AddonImages
+		expected string
+	}{
+		{image: "quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0", expected: "quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0"},
+		{image: "quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0", o: o, expected: "mirror.example.com/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0"},
+		{image: "registry.hub.docker.com/library/registry:2.6.1", o: o, expected: "mirror.example.com/library/registry:2.6.1"},
+		{image: "busybox", o: o, expected: "mirror.example.com/library/busybox:latest"},
+		{image: "k8s.gcr.io/nvidia-gpu-device-plugin@sha256:0842734032018be107fa2490c98156992911e3e1f2a21e059ff0105b07dd8e9e", o: o, expected: "mirror.example.com/nvidia-gpu-device-plugin@sha256:0842734032018be107fa2490c98156992911e3e1f2a21e059ff0105b07dd8e9e"},
+		{image: "nginx:1.15", o: o, expected: "other.example.com/nginx:1.15.1"},
+	}
+	for _, tc := range tcs {
+		if got := OverrideImage(tc.image, tc.o); got != tc.expected {
+			t.Errorf("OverrideImage(%q, %+v) = %q, expected %q", tc.image, tc.o, got, tc.expected)
+		}
+	}
+}
+
+func TestOverrideManifestImages(t *testing.T) {
+	manifest := `containers:
+  - image: "k8s.gcr.io/pause:2.0"
+    name: pause
+  - name: app
+    image: nginx:1.15
+# image: not-an-image
+`
+	expected := `containers:
+  - image: "mirror.example.com/pause:2.0"
+    name: pause
+  - name: app
+    image: other.example.com/nginx:1.15.1
+# image: not-an-image
+`
+	o := config.AddonImages{
+		Registry: "mirror.example.com",
+		Images:   map[string]string{"nginx:1.15": "other.example.com/nginx:1.15.1"},
+	}
+	if got := string(overrideManifestImages([]byte(manifest), o)); got != expected {
+		t.Errorf("unexpected manifest:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRenderAssets(t *testing.T) {
+	cfg := config.KubernetesConfig{
+		AddonImages: map[string]config.AddonImages{
+			"gvisor": {Registry: "mirror.example.com"},
+		},
+	}
+	files, err := Addons["gvisor"].RenderAssets(cfg)
+	if err != nil {
+		t.Fatalf("RenderAssets: %v", err)
+	}
+	if len(files) != len(Addons["gvisor"].Assets) {
+		t.Fatalf("expected %d assets, got %d", len(Addons["gvisor"].Assets), len(files))
+	}
+	for _, f := range files {
+		contents, err := ioutil.ReadAll(f)
+		if err != nil {
+			t.Fatalf("read %s: %v", f.GetTargetName(), err)
+		}
+		if f.GetTargetName() == "gvisor-pod.yaml" && !strings.Contains(string(contents), "image: mirror.example.com/k8s-minikube/gvisor-addon:latest") {
+			t.Errorf("expected the image of the pod to be overridden, got:\n%s", contents)
+		}
+		if !isManifest(f) && strings.Contains(string(contents), "mirror.example.com") {
+			t.Errorf("expected %s not to be overridden, got:\n%s", f.GetTargetName(), contents)
+		}
+	}
+
+	// rendering again must not be affected by the assets read above
+	if _, err := Addons["gvisor"].RenderAssets(cfg); err != nil {
+		t.Errorf("RenderAssets: %v", err)
+	}
+}
+
+func TestImagesFor(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("the images of ingress depend on the architecture")
+	}
+	cfg := config.KubernetesConfig{
+		AddonImages: map[string]config.AddonImages{
+			"ingress": {
+				Registry: "mirror.example.com",
+				Images:   map[string]string{"gcr.io/google_containers/defaultbackend:1.4": "backend:1.4"},
+			},
+		},
+	}
+	images, err := Addons["ingress"].ImagesFor(cfg)
+	if err != nil {
+		t.Fatalf("ImagesFor: %v", err)
+	}
+	expected := []string{"backend:1.4", "mirror.example.com/kubernetes-ingress-controller/nginx-ingress-controller:0.23.0"}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("expected images %v, got %v", expected, images)
+	}
+}
+
+func TestValidateImages(t *testing.T) {
+	cfg := config.KubernetesConfig{
+		AddonImages: map[string]config.AddonImages{
+			"registry": {Registry: "mirror.example.com"},
+		},
+	}
+	if err := Addons["registry"].ValidateImages(cfg, map[string]string{"registry.hub.docker.com/library/registry:2.6.1": "registry:2"}); err != nil {
+		t.Errorf("expected the original image to be valid, got %v", err)
+	}
+	err := Addons["registry"].ValidateImages(cfg, map[string]string{"mirror.example.com/library/registry:2.6.1": "registry:2"})
+	if err == nil || !strings.Contains(err.Error(), "registry.hub.docker.com/library/registry:2.6.1") {
+		t.Errorf("expected an error listing the images of the addon, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// AddonManifestName is the name of the manifest file in the directory of a third-party addon
+const AddonManifestName = "addon.yaml"
+
+// addonNameRegexp matches valid addon names, which are used as config keys
+var addonNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// AddonManifest describes a third-party addon
+type AddonManifest struct {
+	// Name is the name of the addon, as passed to "minikube addons enable"
+	Name string `json:"name"`
+	// Enabled is whether the addon is enabled by default
+	Enabled bool `json:"enabled,omitempty"`
+	// Images are the images the addon requires
+	Images []string `json:"images,omitempty"`
+	// Values are the values accepted by the templates of the addon, with their default
+	Values map[string]string `json:"values,omitempty"`
+	// Requires are the addons which must be enabled before the addon
+	Requires []string `json:"requires,omitempty"`
+	// Conflicts are the addons which cannot be enabled along with the addon
+	Conflicts []string `json:"conflicts,omitempty"`
+	// Pods selects the pods of the addon, whose namespace defaults to kube-system
+	Pods *PodSelector `json:"pods,omitempty"`
+	// Assets are the files of the addon
+	Assets []AddonManifestAsset `json:"assets"`
+}
+
+// AddonManifestAsset is a file of a third-party addon, and where it is copied to in the VM
+type AddonManifestAsset struct {
+	// File is the path of the file, relative to the directory of the addon
+	File string `json:"file"`
+	// TargetDir defaults to the directory applied by the addon manager
+	TargetDir string `json:"targetDir,omitempty"`
+	// TargetName defaults to the name of the file, without any ".tmpl" suffix
+	TargetName string `json:"targetName,omitempty"`
+	// Permissions defaults to 0640
+	Permissions string `json:"permissions,omitempty"`
+	// Template is whether the file is evaluated as a template, like the bundled addons
+	Template bool `json:"template,omitempty"`
+}
+
+// LoadAddonManifest reads the manifest of the addon in dir
+func LoadAddonManifest(dir string) (*AddonManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, AddonManifestName))
+	if err != nil {
+		return nil, err
+	}
+	m := &AddonManifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", AddonManifestName)
+	}
+	return m, nil
+}
+
+// NewAddonFromManifest creates the addon described by a manifest, whose files are relative to dir
+func NewAddonFromManifest(m *AddonManifest, dir string) (*Addon, error) {
+	if !addonNameRegexp.MatchString(m.Name) {
+		return nil, fmt.Errorf("invalid addon name %q: must consist of lower case alphanumeric characters or '-'", m.Name)
+	}
+	if len(m.Assets) == 0 {
+		return nil, fmt.Errorf("addon %s has no assets", m.Name)
+	}
+	if m.Pods != nil {
+		if _, err := labels.Parse(m.Pods.Selector); err != nil {
+			return nil, errors.Wrapf(err, "addon %s: pod selector", m.Name)
+		}
+	}
+
+	var assets []*BinAsset
+	for _, a := range m.Assets {
+		file := filepath.Clean(filepath.FromSlash(a.File))
+		if a.File == "" || filepath.IsAbs(file) || strings.HasPrefix(file, "..") {
+			return nil, fmt.Errorf("addon %s: asset file %q must be relative to the addon directory", m.Name, a.File)
+		}
+		targetDir := a.TargetDir
+		if targetDir == "" {
+			targetDir = constants.AddonsPath
+		}
+		if !path.IsAbs(targetDir) {
+			return nil, fmt.Errorf("addon %s: target directory %q of %s must be absolute", m.Name, targetDir, a.File)
+		}
+		targetName := a.TargetName
+		if targetName == "" {
+			targetName = strings.TrimSuffix(filepath.Base(file), ".tmpl")
+		}
+		permissions := a.Permissions
+		if permissions == "" {
+			permissions = "0640"
+		}
+
+		asset, err := NewBinAssetFromFile(filepath.Join(dir, file), targetDir, targetName, permissions, a.Template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "addon %s: asset %s", m.Name, a.File)
+		}
+		assets = append(assets, asset)
+	}
+
+	addon := NewAddon(assets, m.Enabled, m.Name)
+	addon.Images = m.Images
+	addon.Defaults = m.Values
+	addon.Requires = m.Requires
+	addon.Conflicts = m.Conflicts
+	if m.Pods != nil {
+		namespace := m.Pods.Namespace
+		if namespace == "" {
+			namespace = "kube-system"
+		}
+		addon.withPods(namespace, m.Pods.Selector)
+	}
+	addon.thirdParty = true
+	return addon, nil
+}
+
+// LoadThirdPartyAddons adds the addons found in the subdirectories of dir to Addons.
+// Invalid addons are skipped, and reported in the returned error.
+func LoadThirdPartyAddons(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "reading third-party addons")
+	}
+
+	var errs []string
+	loaded := map[string]string{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		addonDir := filepath.Join(dir, e.Name())
+		m, err := LoadAddonManifest(addonDir)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", addonDir, err))
+			continue
+		}
+		if existing, ok := Addons[m.Name]; ok && !existing.thirdParty {
+			errs = append(errs, fmt.Sprintf("%s: addon %s is already bundled with minikube", addonDir, m.Name))
+			continue
+		}
+		if other, ok := loaded[m.Name]; ok {
+			errs = append(errs, fmt.Sprintf("%s: addon %s is already loaded from %s", addonDir, m.Name, other))
+			continue
+		}
+		addon, err := NewAddonFromManifest(m, addonDir)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", addonDir, err))
+			continue
+		}
+		glog.Infof("Loaded third-party addon %s from %s", m.Name, addonDir)
+		Addons[m.Name] = addon
+		loaded[m.Name] = addonDir
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid third-party addons:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
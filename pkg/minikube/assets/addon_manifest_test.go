@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func writeAddon(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+}
+
+func TestLoadThirdPartyAddons(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addons")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeAddon(t, filepath.Join(dir, "hello"), map[string]string{
+		AddonManifestName: `
+name: hello
+enabled: true
+images:
+- gcr.io/google-samples/hello-app:1.0
+requires:
+- ingress
+pods:
+  selector: app=hello
+assets:
+- file: hello-dp.yaml.tmpl
+  template: true
+- file: extra/hello-svc.yaml
+  targetDir: /etc/kubernetes/manifests
+  targetName: svc.yaml
+  permissions: "0600"
+`,
+		"hello-dp.yaml.tmpl":   "arch: {{.Arch}}",
+		"extra/hello-svc.yaml": "kind: Service",
+	})
+	writeAddon(t, filepath.Join(dir, "bundled"), map[string]string{
+		AddonManifestName: "name: dashboard\nassets:\n- file: a.yaml\n",
+		"a.yaml":          "kind: Service",
+	})
+	writeAddon(t, filepath.Join(dir, "invalid-name"), map[string]string{
+		AddonManifestName: "name: Invalid_Name\nassets:\n- file: a.yaml\n",
+		"a.yaml":          "kind: Service",
+	})
+	writeAddon(t, filepath.Join(dir, "outside"), map[string]string{
+		AddonManifestName: "name: outside\nassets:\n- file: ../hello/hello-dp.yaml.tmpl\n",
+	})
+	writeAddon(t, filepath.Join(dir, "no-manifest"), map[string]string{
+		"a.yaml": "kind: Service",
+	})
+	defer delete(Addons, "hello")
+	bundled := Addons["dashboard"]
+
+	err = LoadThirdPartyAddons(dir)
+	if err == nil {
+		t.Fatalf("expected the invalid addons to be reported")
+	}
+	for _, invalid := range []string{"bundled", "invalid-name", "outside", "no-manifest"} {
+		if !strings.Contains(err.Error(), filepath.Join(dir, invalid)+":") {
+			t.Errorf("expected %s to be reported, got: %v", invalid, err)
+		}
+	}
+	if Addons["dashboard"] != bundled {
+		t.Errorf("expected the bundled dashboard addon to be kept")
+	}
+	if _, ok := Addons["outside"]; ok {
+		t.Errorf("expected the addon with a file outside its directory to be skipped")
+	}
+
+	hello, ok := Addons["hello"]
+	if !ok {
+		t.Fatalf("expected the hello addon to be loaded")
+	}
+	if enabled, err := hello.IsEnabled(); err != nil || !enabled {
+		t.Errorf("expected hello to be enabled by default, got %t, %v", enabled, err)
+	}
+	if len(hello.Images) != 1 || hello.Images[0] != "gcr.io/google-samples/hello-app:1.0" {
+		t.Errorf("unexpected images: %v", hello.Images)
+	}
+	if len(hello.Requires) != 1 || hello.Requires[0] != "ingress" {
+		t.Errorf("unexpected requirements: %v", hello.Requires)
+	}
+	if hello.Pods == nil || hello.Pods.Namespace != "kube-system" || hello.Pods.Selector != "app=hello" {
+		t.Errorf("unexpected pods: %+v", hello.Pods)
+	}
+	if len(hello.Assets) != 2 {
+		t.Fatalf("expected 2 assets, got %d", len(hello.Assets))
+	}
+
+	dp := hello.Assets[0]
+	if dp.GetTargetDir() != constants.AddonsPath || dp.GetTargetName() != "hello-dp.yaml" || dp.GetPermissions() != "0640" {
+		t.Errorf("unexpected defaults: %s/%s %s", dp.GetTargetDir(), dp.GetTargetName(), dp.GetPermissions())
+	}
+	evaluated, err := dp.Evaluate(struct{ Arch string }{"amd64"})
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if contents, _ := ioutil.ReadAll(evaluated); string(contents) != "arch: amd64" {
+		t.Errorf("unexpected evaluated template: %q", contents)
+	}
+
+	svc := hello.Assets[1]
+	if svc.IsTemplate() || svc.GetTargetDir() != "/etc/kubernetes/manifests" || svc.GetTargetName() != "svc.yaml" || svc.GetPermissions() != "0600" {
+		t.Errorf("unexpected asset: template %t, %s/%s %s", svc.IsTemplate(), svc.GetTargetDir(), svc.GetTargetName(), svc.GetPermissions())
+	}
+
+	// loading again replaces the third-party addons
+	if err := LoadThirdPartyAddons(dir); strings.Contains(err.Error(), filepath.Join(dir, "hello")+":") {
+		t.Errorf("expected hello to be loaded again without errors, got: %v", err)
+	}
+}
+
+func TestLoadThirdPartyAddonsMissingDir(t *testing.T) {
+	if err := LoadThirdPartyAddons(filepath.Join(os.TempDir(), "nonexistent-addons")); err != nil {
+		t.Errorf("expected no error for a missing directory, got %v", err)
+	}
+}
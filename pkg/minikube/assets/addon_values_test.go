@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestValidateValues(t *testing.T) {
+	if err := Addons["ingress"].ValidateValues(map[string]string{"extraArgs": "--v=2"}); err != nil {
+		t.Errorf("expected extraArgs to be valid, got %v", err)
+	}
+	err := Addons["ingress"].ValidateValues(map[string]string{"unknown": "x"})
+	if err == nil || !strings.Contains(err.Error(), "extraArgs") {
+		t.Errorf("expected an error listing the valid values, got %v", err)
+	}
+	if err := Addons["freshpod"].ValidateValues(map[string]string{"unknown": "x"}); err == nil {
+		t.Errorf("expected an error for an addon without values")
+	}
+}
+
+func TestValidateValuesContent(t *testing.T) {
+	var tests = []struct {
+		addon string
+		key   string
+		value string
+		valid bool
+	}{
+		{"dashboard", "authenticationMode", "token", true},
+		{"dashboard", "authenticationMode", "basic", true},
+		{"dashboard", "authenticationMode", "token\n        - --enable-skip-login", false},
+		{"registry", "storageSize", "10Gi", true},
+		{"registry", "storageSize", "", true},
+		{"registry", "storageSize", "10Gi\n        medium: Memory", false},
+		{"ingress", "extraArgs", "--v=2 --enable-ssl-passthrough", true},
+		{"ingress", "extraArgs", "--v=2 {}", false},
+	}
+	for _, tc := range tests {
+		err := Addons[tc.addon].ValidateValues(map[string]string{tc.key: tc.value})
+		if tc.valid && err != nil {
+			t.Errorf("expected %s %s=%q to be valid, got %v", tc.addon, tc.key, tc.value, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("expected %s %s=%q to be invalid", tc.addon, tc.key, tc.value)
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	cfg := config.KubernetesConfig{
+		AddonValues: map[string]map[string]string{
+			"dashboard": {"authenticationMode": "basic", "removed": "x"},
+		},
+	}
+	if got := Addons["dashboard"].Values(cfg); !reflect.DeepEqual(got, map[string]string{"authenticationMode": "basic"}) {
+		t.Errorf("unexpected dashboard values: %v", got)
+	}
+	if got := Addons["registry"].Values(cfg); !reflect.DeepEqual(got, map[string]string{"storageSize": ""}) {
+		t.Errorf("unexpected registry values: %v", got)
+	}
+}
+
+// evaluate returns the contents of the asset of an addon, evaluated with the values
+func evaluate(t *testing.T, addon, targetName string, values map[string]string) string {
+	for _, a := range Addons[addon].Assets {
+		if a.GetTargetName() != targetName {
+			continue
+		}
+		cfg := config.KubernetesConfig{AddonValues: map[string]map[string]string{addon: values}}
+		m, err := a.Evaluate(GenerateTemplateData(cfg, Addons[addon].Values(cfg)))
+		if err != nil {
+			t.Fatalf("evaluate %s: %v", targetName, err)
+		}
+		contents, err := ioutil.ReadAll(m)
+		if err != nil {
+			t.Fatalf("read %s: %v", targetName, err)
+		}
+		return string(contents)
+	}
+	t.Fatalf("%s has no asset %s", addon, targetName)
+	return ""
+}
+
+func TestAddonTemplateValues(t *testing.T) {
+	tcs := []struct {
+		addon      string
+		targetName string
+		values     map[string]string
+		expected   []string
+		unexpected []string
+	}{
+		{
+			addon:      "ingress",
+			targetName: "ingress-dp.yaml",
+			values:     map[string]string{"extraArgs": "--enable-ssl-passthrough --v=2"},
+			expected:   []string{"- --report-node-internal-ip-address\n        - --enable-ssl-passthrough\n        - --v=2\n"},
+		},
+		{
+			addon:      "ingress",
+			targetName: "ingress-dp.yaml",
+			expected:   []string{"- --report-node-internal-ip-address\n        securityContext:"},
+		},
+		{
+			addon:      "registry",
+			targetName: "registry-rc.yaml",
+			values:     map[string]string{"storageSize": "10Gi"},
+			expected:   []string{"emptyDir:\n          sizeLimit: 10Gi\n"},
+		},
+		{
+			addon:      "registry",
+			targetName: "registry-rc.yaml",
+			expected:   []string{"emptyDir: {}\n"},
+			unexpected: []string{"sizeLimit"},
+		},
+		{
+			addon:      "dashboard",
+			targetName: "dashboard-dp.yaml",
+			expected:   []string{"- --authentication-mode=token\n"},
+		},
+	}
+	for _, tc := range tcs {
+		contents := evaluate(t, tc.addon, tc.targetName, tc.values)
+		for _, e := range tc.expected {
+			if !strings.Contains(contents, e) {
+				t.Errorf("%s with %v: expected %q in:\n%s", tc.targetName, tc.values, e, contents)
+			}
+		}
+		for _, u := range tc.unexpected {
+			if strings.Contains(contents, u) {
+				t.Errorf("%s with %v: unexpected %q in:\n%s", tc.targetName, tc.values, u, contents)
+			}
+		}
+	}
+}
+
+func TestAddonPodSelectors(t *testing.T) {
+	for name, addon := range Addons {
+		if addon.Pods == nil {
+			continue
+		}
+		if _, err := labels.Parse(addon.Pods.Selector); err != nil {
+			t.Errorf("%s: invalid pod selector %q: %v", name, addon.Pods.Selector, err)
+		}
+		if addon.Pods.Namespace == "" {
+			t.Errorf("%s: the namespace of the pods is not set", name)
+		}
+	}
+}
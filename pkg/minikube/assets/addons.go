@@ -22,9 +22,12 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/util"
@@ -32,9 +35,28 @@ import (
 
 // Addon is a named list of assets, that can be enabled
 type Addon struct {
-	Assets    []*BinAsset
-	enabled   bool
-	addonName string
+	Assets []*BinAsset
+	// Defaults are the values accepted by the templates of the addon, with their default
+	Defaults map[string]string
+	// Pods selects the pods of the addon, if it has any
+	Pods *PodSelector
+	// Images are the images the addon requires, which are cached when it is enabled
+	Images []string
+	// Requires are the addons which must be enabled before the addon
+	Requires []string
+	// Conflicts are the addons which cannot be enabled along with the addon
+	Conflicts  []string
+	enabled    bool
+	addonName  string
+	thirdParty bool
+	// validators check the values which are not injected into the templates as is
+	validators map[string]func(string) error
+}
+
+// PodSelector selects pods by a label selector, in a namespace
+type PodSelector struct {
+	Namespace string `json:"namespace,omitempty"`
+	Selector  string `json:"selector"`
 }
 
 // NewAddon creates a new Addon
@@ -47,6 +69,97 @@ func NewAddon(assets []*BinAsset, enabled bool, addonName string) *Addon {
 	return a
 }
 
+// withDefaults declares the values accepted by the templates of the addon
+func (a *Addon) withDefaults(defaults map[string]string) *Addon {
+	a.Defaults = defaults
+	return a
+}
+
+// withValidators declares the checks of the values of the addon
+func (a *Addon) withValidators(validators map[string]func(string) error) *Addon {
+	a.validators = validators
+	return a
+}
+
+// withPods declares the pods of the addon, selected by a label selector
+func (a *Addon) withPods(namespace, selector string) *Addon {
+	a.Pods = &PodSelector{Namespace: namespace, Selector: selector}
+	return a
+}
+
+// ValidateValues returns an error if the addon does not accept any of the values
+func (a *Addon) ValidateValues(values map[string]string) error {
+	var keys []string
+	for k := range a.Defaults {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for k := range values {
+		if _, ok := a.Defaults[k]; ok {
+			continue
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("addon %s has no values, cannot set %q", a.addonName, k)
+		}
+		return fmt.Errorf("unknown value %q for addon %s, valid values are: %s", k, a.addonName, strings.Join(keys, ", "))
+	}
+	for k, v := range values {
+		validate, ok := a.validators[k]
+		if !ok {
+			continue
+		}
+		if err := validate(v); err != nil {
+			return errors.Wrapf(err, "invalid value %s=%q for addon %s", k, v, a.addonName)
+		}
+	}
+	return nil
+}
+
+// isOneOf returns a validator which accepts only the given values
+func isOneOf(allowed ...string) func(string) error {
+	return func(v string) error {
+		for _, a := range allowed {
+			if v == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of: %s", strings.Join(allowed, ", "))
+	}
+}
+
+// isQuantity accepts a resource quantity, such as "10Gi", or no value
+func isQuantity(v string) error {
+	if v == "" {
+		return nil
+	}
+	_, err := resource.ParseQuantity(v)
+	return err
+}
+
+// isFlags accepts space-separated flags, such as "--v=2 --enable-ssl-passthrough"
+func isFlags(v string) error {
+	for _, f := range strings.Fields(v) {
+		if !strings.HasPrefix(f, "--") {
+			return fmt.Errorf("%q is not a flag", f)
+		}
+	}
+	return nil
+}
+
+// Values returns the values of the addon for a cluster: its defaults, overridden by the values set for the cluster
+func (a *Addon) Values(cfg config.KubernetesConfig) map[string]string {
+	values := map[string]string{}
+	for k, v := range a.Defaults {
+		values[k] = v
+	}
+	for k, v := range cfg.AddonValues[a.addonName] {
+		if _, ok := a.Defaults[k]; ok {
+			values[k] = v
+		}
+	}
+	return values
+}
+
 // IsEnabled checks if an Addon is enabled
 func (a *Addon) IsEnabled() (bool, error) {
 	addonStatusText, err := config.Get(a.addonName)
@@ -69,7 +182,7 @@ var Addons = map[string]*Addon{
 			"addon-manager.yaml.tmpl",
 			"0640",
 			true),
-	}, true, "addon-manager"),
+	}, true, "addon-manager").withPods("kube-system", "kubernetes.io/minikube-addons=addon-manager"),
 	"dashboard": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/dashboard/dashboard-dp.yaml.tmpl",
@@ -83,7 +196,12 @@ var Addons = map[string]*Addon{
 			"dashboard-svc.yaml",
 			"0640",
 			false),
-	}, false, "dashboard"),
+	}, false, "dashboard").withDefaults(map[string]string{
+		// "token" or "basic"
+		"authenticationMode": "token",
+	}).withValidators(map[string]func(string) error{
+		"authenticationMode": isOneOf("token", "basic"),
+	}).withPods("kube-system", "app=kubernetes-dashboard"),
 	"default-storageclass": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/storageclass/storageclass.yaml.tmpl",
@@ -99,7 +217,7 @@ var Addons = map[string]*Addon{
 			"storage-provisioner.yaml",
 			"0640",
 			true),
-	}, true, "storage-provisioner"),
+	}, true, "storage-provisioner").withPods("kube-system", "integration-test=storage-provisioner"),
 	"storage-provisioner-gluster": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/storage-provisioner-gluster/storage-gluster-ns.yaml.tmpl",
@@ -125,7 +243,7 @@ var Addons = map[string]*Addon{
 			"storage-privisioner-glusterfile.yaml",
 			"0640",
 			false),
-	}, false, "storage-provisioner-gluster"),
+	}, false, "storage-provisioner-gluster").withConflicts("default-storageclass").withPods("storage-gluster", "glusterfs in (heketi-pod,pod,file-provisioner-pod)"),
 	"heapster": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/heapster/influx-grafana-rc.yaml.tmpl",
@@ -157,7 +275,7 @@ var Addons = map[string]*Addon{
 			"heapster-svc.yaml",
 			"0640",
 			false),
-	}, false, "heapster"),
+	}, false, "heapster").withPods("kube-system", "k8s-app in (heapster,influx-grafana)"),
 	"efk": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/efk/elasticsearch-rc.yaml.tmpl",
@@ -195,7 +313,7 @@ var Addons = map[string]*Addon{
 			"kibana-svc.yaml",
 			"0640",
 			false),
-	}, false, "efk"),
+	}, false, "efk").withPods("kube-system", "k8s-app in (elasticsearch-logging,fluentd-es,kibana-logging)"),
 	"ingress": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/ingress/ingress-configmap.yaml.tmpl",
@@ -221,7 +339,12 @@ var Addons = map[string]*Addon{
 			"ingress-svc.yaml",
 			"0640",
 			false),
-	}, false, "ingress"),
+	}, false, "ingress").withDefaults(map[string]string{
+		// space-separated flags appended to the arguments of the controller
+		"extraArgs": "",
+	}).withValidators(map[string]func(string) error{
+		"extraArgs": isFlags,
+	}).withPods("kube-system", "app.kubernetes.io/name in (default-http-backend,nginx-ingress-controller)"),
 	"metrics-server": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/metrics-server/metrics-apiservice.yaml.tmpl",
@@ -241,21 +364,26 @@ var Addons = map[string]*Addon{
 			"metrics-server-service.yaml",
 			"0640",
 			false),
-	}, false, "metrics-server"),
+	}, false, "metrics-server").withPods("kube-system", "k8s-app=metrics-server"),
 	"registry": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/registry/registry-rc.yaml.tmpl",
 			constants.AddonsPath,
 			"registry-rc.yaml",
 			"0640",
-			false),
+			true),
 		MustBinAsset(
 			"deploy/addons/registry/registry-svc.yaml.tmpl",
 			constants.AddonsPath,
 			"registry-svc.yaml",
 			"0640",
 			false),
-	}, false, "registry"),
+	}, false, "registry").withDefaults(map[string]string{
+		// sizeLimit of the registry's emptyDir volume, e.g. "10Gi"; unlimited by default
+		"storageSize": "",
+	}).withValidators(map[string]func(string) error{
+		"storageSize": isQuantity,
+	}).withPods("kube-system", "kubernetes.io/minikube-addons=registry"),
 	"registry-creds": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/registry-creds/registry-creds-rc.yaml.tmpl",
@@ -263,7 +391,7 @@ var Addons = map[string]*Addon{
 			"registry-creds-rc.yaml",
 			"0640",
 			false),
-	}, false, "registry-creds"),
+	}, false, "registry-creds").withPods("kube-system", "name=registry-creds"),
 	"freshpod": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/freshpod/freshpod-rc.yaml.tmpl",
@@ -271,7 +399,7 @@ var Addons = map[string]*Addon{
 			"freshpod-rc.yaml",
 			"0640",
 			true),
-	}, false, "freshpod"),
+	}, false, "freshpod").withPods("kube-system", "k8s-app=freshpod"),
 	"nvidia-driver-installer": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/gpu/nvidia-driver-installer.yaml.tmpl",
@@ -279,7 +407,7 @@ var Addons = map[string]*Addon{
 			"nvidia-driver-installer.yaml",
 			"0640",
 			true),
-	}, false, "nvidia-driver-installer"),
+	}, false, "nvidia-driver-installer").withPods("kube-system", "k8s-app=nvidia-driver-installer"),
 	"nvidia-gpu-device-plugin": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/gpu/nvidia-gpu-device-plugin.yaml.tmpl",
@@ -287,7 +415,7 @@ var Addons = map[string]*Addon{
 			"nvidia-gpu-device-plugin.yaml",
 			"0640",
 			true),
-	}, false, "nvidia-gpu-device-plugin"),
+	}, false, "nvidia-gpu-device-plugin").withRequires("nvidia-driver-installer").withPods("kube-system", "k8s-app=nvidia-gpu-device-plugin"),
 	"logviewer": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/logviewer/logviewer-dp-and-svc.yaml.tmpl",
@@ -301,7 +429,7 @@ var Addons = map[string]*Addon{
 			"logviewer-rbac.yaml",
 			"0640",
 			false),
-	}, false, "logviewer"),
+	}, false, "logviewer").withPods("kube-system", "app=logviewer"),
 	"gvisor": NewAddon([]*BinAsset{
 		MustBinAsset(
 			"deploy/addons/gvisor/gvisor-pod.yaml.tmpl",
@@ -321,7 +449,7 @@ var Addons = map[string]*Addon{
 			constants.GvisorContainerdShimTargetName,
 			"0640",
 			false),
-	}, false, "gvisor"),
+	}, false, "gvisor").withPods("kube-system", "kubernetes.io/minikube-addons=gvisor"),
 }
 
 // AddMinikubeDirAssets adds all addons and files to the list
@@ -379,8 +507,8 @@ func addMinikubeDirToAssets(basedir, vmpath string, assets *[]CopyableFile) erro
 	return nil
 }
 
-// GenerateTemplateData generates template data for template assets
-func GenerateTemplateData(cfg config.KubernetesConfig) interface{} {
+// GenerateTemplateData generates template data for template assets, with the values of the addon
+func GenerateTemplateData(cfg config.KubernetesConfig, values map[string]string) interface{} {
 
 	a := runtime.GOARCH
 	// Some legacy docker images still need the -arch suffix
@@ -393,10 +521,12 @@ func GenerateTemplateData(cfg config.KubernetesConfig) interface{} {
 		Arch            string
 		ExoticArch      string
 		ImageRepository string
+		Values          map[string]string
 	}{
 		Arch:            a,
 		ExoticArch:      ea,
 		ImageRepository: cfg.ImageRepository,
+		Values:          values,
 	}
 
 	return opts
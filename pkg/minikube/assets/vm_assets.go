@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
@@ -190,14 +192,36 @@ func defaultValue(defValue string, val interface{}) string {
 	return strVal
 }
 
+// NewBinAssetFromFile creates a new BinAsset from a file on the host, rather than from bindata
+func NewBinAssetFromFile(path, targetDir, targetName, permissions string, isTemplate bool) (*BinAsset, error) {
+	m := &BinAsset{
+		BaseAsset: BaseAsset{
+			AssetName:   path,
+			TargetDir:   targetDir,
+			TargetName:  targetName,
+			Permissions: permissions,
+		},
+		template: nil,
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	err = m.setData(contents, isTemplate)
+	return m, err
+}
+
 func (m *BinAsset) loadData(isTemplate bool) error {
 	contents, err := Asset(m.AssetName)
 	if err != nil {
 		return err
 	}
+	return m.setData(contents, isTemplate)
+}
 
+func (m *BinAsset) setData(contents []byte, isTemplate bool) error {
 	if isTemplate {
-		tpl, err := template.New(m.AssetName).Funcs(template.FuncMap{"default": defaultValue}).Parse(string(contents))
+		tpl, err := template.New(m.AssetName).Funcs(template.FuncMap{"default": defaultValue, "fields": strings.Fields}).Parse(string(contents))
 		if err != nil {
 			return err
 		}
@@ -19,6 +19,8 @@ package bootstrapper
 import (
 	"net"
 
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 )
@@ -38,7 +40,18 @@ type Bootstrapper interface {
 	StartCluster(config.KubernetesConfig) error
 	UpdateCluster(config.KubernetesConfig) error
 	RestartCluster(config.KubernetesConfig) error
+	// UpgradeCluster upgrades the running cluster to the Kubernetes version of the config, once UpdateCluster
+	// copied the binaries of that version, and verifies that its components run it.
+	UpgradeCluster(config.KubernetesConfig) error
 	DeleteCluster(config.KubernetesConfig) error
+	// JoinNode joins the worker node reachable through the CommandRunner to the cluster.
+	JoinNode(cfg config.KubernetesConfig, nodeName string, r CommandRunner) error
+	// RemoveNode removes the named worker node from the cluster.
+	RemoveNode(cfg config.KubernetesConfig, nodeName string) error
+	// SaveSnapshot returns a compressed archive of the etcd data and certificates of the cluster.
+	SaveSnapshot(config.KubernetesConfig) ([]byte, error)
+	// RestoreSnapshot replaces the etcd data and certificates of the cluster with those of an archive.
+	RestoreSnapshot(config.KubernetesConfig, []byte) error
 	// LogCommands returns a map of log type to a command which will display that log.
 	LogCommands(LogOptions) map[string]string
 	SetupCerts(cfg config.KubernetesConfig) error
@@ -49,6 +62,8 @@ type Bootstrapper interface {
 const (
 	// BootstrapperTypeKubeadm is the kubeadm bootstrapper type
 	BootstrapperTypeKubeadm = "kubeadm"
+	// BootstrapperTypeK3s is the k3s bootstrapper type
+	BootstrapperTypeK3s = "k3s"
 )
 
 // GetCachedBinaryList returns the list of binaries
@@ -56,6 +71,8 @@ func GetCachedBinaryList(bootstrapper string) []string {
 	switch bootstrapper {
 	case BootstrapperTypeKubeadm:
 		return constants.GetKubeadmCachedBinaries()
+	case BootstrapperTypeK3s:
+		return constants.GetK3sCachedBinaries()
 	default:
 		return []string{}
 	}
@@ -71,3 +88,26 @@ func GetCachedImageList(imageRepository string, version string, bootstrapper str
 		return []string{}
 	}
 }
+
+// AddAddons adds the files of the custom addons, and of the enabled bundled addons, to the files to copy
+func AddAddons(files *[]assets.CopyableFile, cfg config.KubernetesConfig) error {
+	// add addons to file list
+	// custom addons
+	if err := assets.AddMinikubeDirAssets(files); err != nil {
+		return errors.Wrap(err, "adding minikube dir assets")
+	}
+	// bundled addons
+	for _, addonBundle := range assets.Addons {
+		if isEnabled, err := addonBundle.IsEnabled(); err == nil && isEnabled {
+			addonFiles, err := addonBundle.RenderAssets(cfg)
+			if err != nil {
+				return errors.Wrap(err, "rendering bundled addon")
+			}
+			*files = append(*files, addonFiles...)
+		} else if err != nil {
+			return nil
+		}
+	}
+
+	return nil
+}
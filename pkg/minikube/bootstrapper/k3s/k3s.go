@@ -0,0 +1,459 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3s
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/util"
+)
+
+// componentArgs maps the components of --extra-config to the k3s flags passing arguments to them
+var componentArgs = map[string]string{
+	"apiserver":          "kube-apiserver-arg",
+	"controller-manager": "kube-controller-arg",
+	"scheduler":          "kube-scheduler-arg",
+	"kubelet":            "kubelet-arg",
+	"proxy":              "kube-proxy-arg",
+}
+
+// tlsDir is where k3s keeps its certificates. It only generates the CAs which are missing,
+// so that the CA of minikube is used for both the serving and the client certificates.
+var tlsDir = path.Join(constants.K3sDataDir, "server", "tls")
+
+// nodeTokenFile is where the k3s server writes the token agents join with
+var nodeTokenFile = path.Join(constants.K3sDataDir, "server", "node-token")
+
+// Bootstrapper is a bootstrapper using k3s
+type Bootstrapper struct {
+	c bootstrapper.CommandRunner
+}
+
+// NewK3sBootstrapper creates a new k3s.Bootstrapper
+func NewK3sBootstrapper(api libmachine.API) (*Bootstrapper, error) {
+	h, err := api.Load(config.GetMachineName())
+	if err != nil {
+		return nil, errors.Wrap(err, "getting api client")
+	}
+	runner, err := machine.CommandRunner(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "command runner")
+	}
+	return &Bootstrapper{c: runner}, nil
+}
+
+// GetKubeletStatus returns the status of k3s, which runs the kubelet
+func (k *Bootstrapper) GetKubeletStatus() (string, error) {
+	status, err := k.c.CombinedOutput("sudo systemctl is-active k3s")
+	if err != nil {
+		// is-active exits with an error for any state but active
+		glog.Infof("k3s status: %v", err)
+	}
+	switch strings.TrimSpace(status) {
+	case "active":
+		return state.Running.String(), nil
+	case "inactive", "failed":
+		return state.Stopped.String(), nil
+	case "activating":
+		return state.Starting.String(), nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "getting status")
+	}
+	return state.Error.String(), nil
+}
+
+// GetAPIServerStatus returns the api-server status. k3s disables anonymous requests, so the
+// health check authenticates with the client certificate of minikube.
+func (k *Bootstrapper) GetAPIServerStatus(ip net.IP, apiserverPort int) (string, error) {
+	url := fmt.Sprintf("https://%s:%d/healthz", ip, apiserverPort)
+	// To avoid: x509: certificate signed by unknown authority
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	cert, err := tls.LoadX509KeyPair(constants.MakeMiniPath("client.crt"), constants.MakeMiniPath("client.key"))
+	if err != nil {
+		glog.Warningf("unable to load the client certificate: %v", err)
+	} else {
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(url)
+	glog.Infof("%s response: %v %+v", url, err, resp)
+	// Connection refused, usually.
+	if err != nil {
+		return state.Stopped.String(), nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return state.Error.String(), nil
+	}
+	return state.Running.String(), nil
+}
+
+// LogCommands returns a map of log type to a command which will display that log.
+func (k *Bootstrapper) LogCommands(o bootstrapper.LogOptions) map[string]string {
+	var k3s strings.Builder
+	k3s.WriteString("journalctl -u k3s")
+	if o.Lines > 0 {
+		k3s.WriteString(fmt.Sprintf(" -n %d", o.Lines))
+	}
+	if o.Follow {
+		k3s.WriteString(" -f")
+	}
+
+	var dmesg strings.Builder
+	dmesg.WriteString("sudo dmesg -PH -L=never --level warn,err,crit,alert,emerg")
+	if o.Follow {
+		dmesg.WriteString(" --follow")
+	}
+	if o.Lines > 0 {
+		dmesg.WriteString(fmt.Sprintf(" | tail -n %d", o.Lines))
+	}
+	return map[string]string{
+		"k3s":   k3s.String(),
+		"dmesg": dmesg.String(),
+	}
+}
+
+// serverFlags returns the flags of the k3s server for a cluster
+func serverFlags(k8s config.KubernetesConfig) ([]string, error) {
+	if k8s.ConfigPatch != "" {
+		return nil, fmt.Errorf("k3s has no kubeadm configuration to patch")
+	}
+	nodePort := k8s.NodePort
+	if nodePort <= 0 {
+		nodePort = util.APIServerPort
+	}
+	serviceCIDR := k8s.ServiceCIDR
+	if serviceCIDR == "" {
+		serviceCIDR = util.DefaultServiceCIDR
+	}
+	flags := []string{
+		"--data-dir=" + constants.K3sDataDir,
+		fmt.Sprintf("--https-listen-port=%d", nodePort),
+		"--service-cidr=" + serviceCIDR,
+		// minikube has its own ingress addon
+		"--no-deploy=traefik",
+		// the addon manager runs as a static pod
+		"--kubelet-arg=pod-manifest-path=/etc/kubernetes/manifests",
+	}
+	if k8s.NodeName != "" {
+		flags = append(flags, "--node-name="+k8s.NodeName)
+	}
+	if k8s.NodeIP != "" {
+		flags = append(flags, "--node-ip="+k8s.NodeIP, "--tls-san="+k8s.NodeIP)
+	}
+	if k8s.APIServerName != "" {
+		flags = append(flags, "--tls-san="+k8s.APIServerName)
+	}
+	for _, name := range k8s.APIServerNames {
+		flags = append(flags, "--tls-san="+name)
+	}
+	for _, ip := range k8s.APIServerIPs {
+		flags = append(flags, "--tls-san="+ip.String())
+	}
+	if k8s.NetworkPlugin == "cni" {
+		flags = append(flags, "--no-flannel")
+	}
+	if k8s.FeatureGates != "" {
+		for _, c := range []string{"apiserver", "controller-manager", "scheduler", "kubelet"} {
+			flags = append(flags, fmt.Sprintf("--%s=feature-gates=%s", componentArgs[c], k8s.FeatureGates))
+		}
+	}
+
+	var extra []string
+	for _, eo := range k8s.ExtraOptions {
+		arg, ok := componentArgs[eo.Component]
+		if !ok {
+			return nil, fmt.Errorf("k3s does not support extra configuration for %s", eo.Component)
+		}
+		extra = append(extra, fmt.Sprintf("--%s=%s=%s", arg, eo.Key, eo.Value))
+	}
+	sort.Strings(extra)
+	flags = append(flags, extra...)
+	return append(flags, runtimeFlags(k8s)...), nil
+}
+
+// runtimeFlags returns the flags selecting the container runtime of the cluster, rather than the containerd of k3s
+func runtimeFlags(k8s config.KubernetesConfig) []string {
+	switch k8s.ContainerRuntime {
+	case "", "docker":
+		return []string{"--docker"}
+	}
+	cr, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Socket: k8s.CRISocket})
+	if err != nil {
+		glog.Warningf("unknown runtime %q, using the containerd of k3s: %v", k8s.ContainerRuntime, err)
+		return nil
+	}
+	return []string{"--container-runtime-endpoint=" + cr.SocketPath()}
+}
+
+// serviceFile returns the systemd service running k3s with a command and flags
+func serviceFile(k8s config.KubernetesConfig, command string, flags []string, target string) (assets.CopyableFile, error) {
+	b := bytes.Buffer{}
+	opts := struct {
+		Command string
+		Flags   string
+		Docker  bool
+	}{
+		Command: command,
+		Flags:   strings.Join(flags, " "),
+		Docker:  k8s.ContainerRuntime == "" || k8s.ContainerRuntime == "docker",
+	}
+	if err := k3sServiceTemplate.Execute(&b, opts); err != nil {
+		return nil, err
+	}
+	return assets.NewMemoryAssetTarget(b.Bytes(), target, "0640"), nil
+}
+
+// installBinary copies the cached k3s binary onto a node. The kubectl which k3s embeds is linked in the
+// binaries directory of minikube, where addons are applied from, so that the kubectl of the host is kept.
+func installBinary(k8s config.KubernetesConfig, r bootstrapper.CommandRunner) error {
+	for _, bin := range constants.GetK3sCachedBinaries() {
+		p, err := machine.CacheBinary(bin, k8s.KubernetesVersion, "linux", runtime.GOARCH)
+		if err != nil {
+			return errors.Wrapf(err, "downloading %s", bin)
+		}
+		if err := machine.CopyBinary(r, bin, p); err != nil {
+			return errors.Wrapf(err, "copying %s", bin)
+		}
+	}
+	dir := constants.GetGuestBinariesDir(k8s.KubernetesVersion)
+	return r.Run(fmt.Sprintf("sudo mkdir -p %s && sudo ln -sf /usr/bin/k3s %s", dir, path.Join(dir, "kubectl")))
+}
+
+// UpdateCluster copies k3s, its service and the addons into the VM
+func (k *Bootstrapper) UpdateCluster(k8s config.KubernetesConfig) error {
+	if _, err := constants.GetK3sReleaseURL(k8s.KubernetesVersion, runtime.GOARCH); err != nil {
+		return err
+	}
+	flags, err := serverFlags(k8s)
+	if err != nil {
+		return errors.Wrap(err, "generating k3s flags")
+	}
+	service, err := serviceFile(k8s, "server", flags, constants.K3sServiceFile)
+	if err != nil {
+		return errors.Wrap(err, "generating k3s service")
+	}
+	glog.Infof("k3s %s flags: %s", k8s.KubernetesVersion, strings.Join(flags, " "))
+
+	if err := installBinary(k8s, k.c); err != nil {
+		return errors.Wrap(err, "installing k3s")
+	}
+	files := []assets.CopyableFile{service}
+	if err := bootstrapper.AddAddons(&files, k8s); err != nil {
+		return errors.Wrap(err, "adding addons")
+	}
+	for _, f := range files {
+		if err := k.c.Copy(f); err != nil {
+			return errors.Wrapf(err, "copy")
+		}
+	}
+	return k.c.Run("sudo systemctl daemon-reload")
+}
+
+// startServer (re)starts the k3s server, with the CA of minikube, and waits for its pods
+func (k *Bootstrapper) startServer(k8s config.KubernetesConfig) error {
+	cmds := []string{fmt.Sprintf("sudo mkdir -p %s", tlsDir)}
+	for _, ca := range []string{"server-ca", "client-ca"} {
+		for _, ext := range []string{"crt", "key"} {
+			cmds = append(cmds, fmt.Sprintf("sudo cp %s %s",
+				path.Join(util.DefaultCertPath, "ca."+ext), path.Join(tlsDir, ca+"."+ext)))
+		}
+	}
+	cmds = append(cmds, "sudo systemctl enable k3s", "sudo systemctl restart k3s")
+
+	// Run commands one at a time so that it is easier to root cause failures.
+	for _, cmd := range cmds {
+		if out, err := k.c.CombinedOutput(cmd); err != nil {
+			return errors.Wrapf(err, "running cmd: %s\n%s", cmd, out)
+		}
+	}
+	return waitForPods(k8s)
+}
+
+// waitForPods waits until the DNS of the cluster, which k3s deploys, is running
+func waitForPods(k8s config.KubernetesConfig) error {
+	// Like with kubeadm, DNS only runs once the CNI plugin the user installs is up
+	if k8s.NetworkPlugin == "cni" {
+		return nil
+	}
+	client, err := util.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "k8s client")
+	}
+	console.OutStyle("waiting", "Waiting for the cluster DNS to be running ...")
+	selector := labels.SelectorFromSet(labels.Set{"k8s-app": "kube-dns"})
+	if err := util.WaitForPodsWithLabelRunning(client, "kube-system", selector); err != nil {
+		return errors.Wrap(err, "waiting for k8s-app=kube-dns")
+	}
+	return nil
+}
+
+// StartCluster starts the cluster
+func (k *Bootstrapper) StartCluster(k8s config.KubernetesConfig) error {
+	return k.startServer(k8s)
+}
+
+// RestartCluster restarts the cluster, from the state k3s stored
+func (k *Bootstrapper) RestartCluster(k8s config.KubernetesConfig) error {
+	return k.startServer(k8s)
+}
+
+// UpgradeCluster restarts k3s, which UpdateCluster replaced with the release shipping the new version
+func (k *Bootstrapper) UpgradeCluster(k8s config.KubernetesConfig) error {
+	if err := k.startServer(k8s); err != nil {
+		return err
+	}
+	console.OutStyle("verifying", "Verifying the version of the Kubernetes components ...")
+	// The node reports the version of the kubelet a few seconds after it started.
+	verify := func() error {
+		client, err := util.GetClient()
+		if err != nil {
+			return errors.Wrap(err, "k8s client")
+		}
+		versions, err := bootstrapper.ComponentVersions(client, k8s.NodeName)
+		if err != nil {
+			return err
+		}
+		return bootstrapper.CheckVersions(k8s.KubernetesVersion, versions)
+	}
+	if err := util.RetryAfter(10, verify, 3*time.Second); err != nil {
+		return errors.Wrap(err, "verifying versions")
+	}
+	return nil
+}
+
+// stopContainers stops the containers of the pods of the cluster, which outlive k3s with an external runtime
+func stopContainers(k8s config.KubernetesConfig, r bootstrapper.CommandRunner) error {
+	cr, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Socket: k8s.CRISocket, Runner: r})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+	for _, s := range []cruntime.ContainerState{cruntime.ContainerPaused, cruntime.ContainerRunning} {
+		ids, err := cr.ListKubernetesContainers(s)
+		if err != nil {
+			return errors.Wrapf(err, "list %s", s)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if s == cruntime.ContainerPaused {
+			if err := cr.UnpauseContainers(ids); err != nil {
+				return errors.Wrap(err, "unpause")
+			}
+		}
+		if err := cr.StopContainers(ids); err != nil {
+			return errors.Wrap(err, "stop")
+		}
+	}
+	return nil
+}
+
+// DeleteCluster stops k3s, and removes the state of the cluster
+func (k *Bootstrapper) DeleteCluster(k8s config.KubernetesConfig) error {
+	if out, err := k.c.CombinedOutput("sudo systemctl disable --now k3s"); err != nil {
+		return errors.Wrapf(err, "stopping k3s: %s", out)
+	}
+	if err := stopContainers(k8s, k.c); err != nil {
+		return errors.Wrap(err, "stopping containers")
+	}
+	cmd := fmt.Sprintf("sudo rm -rf %s", constants.K3sDataDir)
+	if out, err := k.c.CombinedOutput(cmd); err != nil {
+		return errors.Wrapf(err, "running cmd: %s\n%s", cmd, out)
+	}
+	return nil
+}
+
+// JoinNode runs a k3s agent on a worker node, reachable through the given runner, joining the cluster
+func (k *Bootstrapper) JoinNode(k8s config.KubernetesConfig, nodeName string, r bootstrapper.CommandRunner) error {
+	out, err := k.c.CombinedOutput(fmt.Sprintf("sudo cat %s", nodeTokenFile))
+	if err != nil {
+		return errors.Wrapf(err, "reading node token: %s", out)
+	}
+	token := strings.TrimSpace(out)
+
+	nodePort := k8s.NodePort
+	if nodePort <= 0 {
+		nodePort = util.APIServerPort
+	}
+	flags := []string{
+		"--data-dir=" + constants.K3sDataDir,
+		fmt.Sprintf("--server=https://%s:%d", k8s.NodeIP, nodePort),
+		"--token=" + token,
+		"--node-name=" + nodeName,
+	}
+	flags = append(flags, runtimeFlags(k8s)...)
+	service, err := serviceFile(k8s, "agent", flags, constants.K3sAgentServiceFile)
+	if err != nil {
+		return errors.Wrap(err, "generating k3s agent service")
+	}
+
+	if err := installBinary(k8s, r); err != nil {
+		return errors.Wrap(err, "installing k3s")
+	}
+	if err := r.Copy(service); err != nil {
+		return errors.Wrap(err, "copy")
+	}
+	return r.Run("sudo systemctl daemon-reload && sudo systemctl enable k3s-agent && sudo systemctl restart k3s-agent")
+}
+
+// RemoveNode removes a worker node from the cluster
+func (k *Bootstrapper) RemoveNode(k8s config.KubernetesConfig, nodeName string) error {
+	client, err := util.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "k8s client")
+	}
+	err = client.CoreV1().Nodes().Delete(nodeName, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting node %s", nodeName)
+	}
+	return nil
+}
+
+// PullImages does nothing, as k3s pulls the few images it needs itself
+func (k *Bootstrapper) PullImages(k8s config.KubernetesConfig) error {
+	glog.Infof("k3s pulls its images on start")
+	return nil
+}
+
+// SetupCerts sets up certificates within the cluster.
+func (k *Bootstrapper) SetupCerts(k8s config.KubernetesConfig) error {
+	return bootstrapper.SetupCerts(k.c, k8s)
+}
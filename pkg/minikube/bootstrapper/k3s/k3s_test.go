@@ -0,0 +1,209 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3s
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/machine/libmachine/state"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/util"
+)
+
+func TestServerFlags(t *testing.T) {
+	tests := []struct {
+		description string
+		cfg         config.KubernetesConfig
+		expected    []string
+		shouldErr   bool
+	}{
+		{
+			description: "defaults",
+			cfg:         config.KubernetesConfig{},
+			expected: []string{
+				"--data-dir=/var/lib/minikube/k3s",
+				"--https-listen-port=8443",
+				"--service-cidr=10.96.0.0/12",
+				"--no-deploy=traefik",
+				"--kubelet-arg=pod-manifest-path=/etc/kubernetes/manifests",
+				"--docker",
+			},
+		},
+		{
+			description: "node and api server names",
+			cfg: config.KubernetesConfig{
+				NodePort:       18443,
+				NodeName:       "minikube",
+				NodeIP:         "192.168.99.100",
+				ServiceCIDR:    "10.0.0.0/16",
+				APIServerName:  "minikubeCA",
+				APIServerNames: []string{"cluster.local"},
+				APIServerIPs:   []net.IP{net.ParseIP("10.0.0.1")},
+				NetworkPlugin:  "cni",
+			},
+			expected: []string{
+				"--data-dir=/var/lib/minikube/k3s",
+				"--https-listen-port=18443",
+				"--service-cidr=10.0.0.0/16",
+				"--no-deploy=traefik",
+				"--kubelet-arg=pod-manifest-path=/etc/kubernetes/manifests",
+				"--node-name=minikube",
+				"--node-ip=192.168.99.100",
+				"--tls-san=192.168.99.100",
+				"--tls-san=minikubeCA",
+				"--tls-san=cluster.local",
+				"--tls-san=10.0.0.1",
+				"--no-flannel",
+				"--docker",
+			},
+		},
+		{
+			description: "extra options and feature gates",
+			cfg: config.KubernetesConfig{
+				ContainerRuntime: "containerd",
+				FeatureGates:     "a=true",
+				ExtraOptions: util.ExtraOptionSlice{
+					{Component: "kubelet", Key: "max-pods", Value: "20"},
+					{Component: "apiserver", Key: "v", Value: "2"},
+				},
+			},
+			expected: []string{
+				"--data-dir=/var/lib/minikube/k3s",
+				"--https-listen-port=8443",
+				"--service-cidr=10.96.0.0/12",
+				"--no-deploy=traefik",
+				"--kubelet-arg=pod-manifest-path=/etc/kubernetes/manifests",
+				"--kube-apiserver-arg=feature-gates=a=true",
+				"--kube-controller-arg=feature-gates=a=true",
+				"--kube-scheduler-arg=feature-gates=a=true",
+				"--kubelet-arg=feature-gates=a=true",
+				"--kube-apiserver-arg=v=2",
+				"--kubelet-arg=max-pods=20",
+				"--container-runtime-endpoint=/run/containerd/containerd.sock",
+			},
+		},
+		{
+			description: "unsupported component",
+			cfg: config.KubernetesConfig{
+				ExtraOptions: util.ExtraOptionSlice{{Component: "etcd", Key: "quota-backend-bytes", Value: "1"}},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual, err := serverFlags(test.cfg)
+			if err != nil && !test.shouldErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && test.shouldErr {
+				t.Fatalf("expected an error, got flags %v", actual)
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Actual: %v, Expected: %v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestServiceFile(t *testing.T) {
+	f, err := serviceFile(config.KubernetesConfig{}, "server", []string{"--docker", "--no-flannel"}, "/lib/systemd/system/k3s.service")
+	if err != nil {
+		t.Fatalf("serviceFile: %v", err)
+	}
+	if f.GetTargetDir() != "/lib/systemd/system" || f.GetTargetName() != "k3s.service" {
+		t.Errorf("unexpected target %s/%s", f.GetTargetDir(), f.GetTargetName())
+	}
+	b := make([]byte, f.GetLength())
+	if _, err := f.Read(b); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	for _, line := range []string{"Wants=docker.socket", "ExecStart=/usr/bin/k3s server --docker --no-flannel"} {
+		if !strings.Contains(string(b), line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, b)
+		}
+	}
+
+	f, err = serviceFile(config.KubernetesConfig{ContainerRuntime: "crio"}, "agent", nil, "/lib/systemd/system/k3s-agent.service")
+	if err != nil {
+		t.Fatalf("serviceFile: %v", err)
+	}
+	b = make([]byte, f.GetLength())
+	if _, err := f.Read(b); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if strings.Contains(string(b), "docker.socket") {
+		t.Errorf("unexpected docker dependency in:\n%s", b)
+	}
+	if !strings.Contains(string(b), "ExecStart=/usr/bin/k3s agent\n") {
+		t.Errorf("expected the agent command in:\n%s", b)
+	}
+}
+
+func TestLogCommands(t *testing.T) {
+	k := &Bootstrapper{c: bootstrapper.NewFakeCommandRunner()}
+	got := k.LogCommands(bootstrapper.LogOptions{Lines: 10, Follow: true})
+	if got["k3s"] != "journalctl -u k3s -n 10 -f" {
+		t.Errorf("unexpected k3s command %q", got["k3s"])
+	}
+	if _, ok := got["dmesg"]; !ok {
+		t.Errorf("expected a dmesg command in %v", got)
+	}
+}
+
+func TestGetKubeletStatus(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{output: "active\n", expected: state.Running.String()},
+		{output: "inactive\n", expected: state.Stopped.String()},
+		{output: "activating\n", expected: state.Starting.String()},
+	}
+	for _, test := range tests {
+		f := bootstrapper.NewFakeCommandRunner()
+		f.SetCommandToOutput(map[string]string{"sudo systemctl is-active k3s": test.output})
+		k := &Bootstrapper{c: f}
+		got, err := k.GetKubeletStatus()
+		if err != nil {
+			t.Fatalf("GetKubeletStatus: %v", err)
+		}
+		if got != test.expected {
+			t.Errorf("GetKubeletStatus() with %q = %s, want %s", test.output, got, test.expected)
+		}
+	}
+}
+
+func TestDeleteCluster(t *testing.T) {
+	f := bootstrapper.NewFakeCommandRunner()
+	f.SetCommandToOutput(map[string]string{
+		"sudo systemctl disable --now k3s":                                            "",
+		`docker ps --filter="name=k8s_" --filter="status=paused" --format="{{.ID}}"`:  "",
+		`docker ps --filter="name=k8s_" --filter="status=running" --format="{{.ID}}"`: "abc\n",
+		"docker stop abc":                   "",
+		"sudo rm -rf /var/lib/minikube/k3s": "",
+	})
+	k := &Bootstrapper{c: f}
+	if err := k.DeleteCluster(config.KubernetesConfig{ContainerRuntime: "docker"}); err != nil {
+		t.Fatalf("DeleteCluster: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3s
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/util"
+)
+
+// snapshotArchive is where the snapshot archive is staged on the host
+const snapshotArchive = "/tmp/minikube-snapshot.tar.gz"
+
+// serverDir is where the k3s server stores the datastore, certificates and token of the cluster
+var serverDir = path.Join(constants.K3sDataDir, "server")
+
+// snapshotDirs are the directories captured by a snapshot, relative to /
+var snapshotDirs = []string{
+	strings.TrimPrefix(serverDir, "/"),
+	strings.TrimPrefix(path.Clean(util.DefaultCertPath), "/"),
+}
+
+// SaveSnapshot returns a compressed archive of the datastore and certificates of the cluster.
+// The cluster should be paused, so that the datastore is consistent.
+func (k *Bootstrapper) SaveSnapshot(k8s config.KubernetesConfig) ([]byte, error) {
+	cmd := fmt.Sprintf("sudo tar -C / -czf %s %s", snapshotArchive, strings.Join(snapshotDirs, " "))
+	if out, err := k.c.CombinedOutput(cmd); err != nil {
+		return nil, errors.Wrapf(err, "archive: %s", out)
+	}
+	defer func() {
+		if err := k.c.Run(fmt.Sprintf("sudo rm -f %s", snapshotArchive)); err != nil {
+			glog.Warningf("unable to remove %s: %v", snapshotArchive, err)
+		}
+	}()
+
+	// CommandRunner has no way to copy files from the host, so transfer the archive as text.
+	out, err := k.c.CombinedOutput(fmt.Sprintf("sudo base64 %s", snapshotArchive))
+	if err != nil {
+		return nil, errors.Wrapf(err, "read archive: %s", out)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(out))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode archive")
+	}
+	return data, nil
+}
+
+// RestoreSnapshot replaces the datastore and certificates of the cluster with those of an archive.
+// RestartCluster should be called afterwards to start k3s from the restored data.
+func (k *Bootstrapper) RestoreSnapshot(k8s config.KubernetesConfig, data []byte) error {
+	f := assets.NewMemoryAssetTarget(data, snapshotArchive, "0640")
+	if err := k.c.Copy(f); err != nil {
+		return errors.Wrap(err, "copy archive")
+	}
+
+	// The datastore must not be in use while it is replaced.
+	if err := k.c.Run("sudo systemctl stop k3s"); err != nil {
+		return errors.Wrap(err, "stopping k3s")
+	}
+	if err := stopContainers(k8s, k.c); err != nil {
+		return errors.Wrap(err, "stopping containers")
+	}
+
+	cmds := []string{
+		fmt.Sprintf("sudo rm -rf %s", serverDir),
+		fmt.Sprintf("sudo tar -C / -xzf %s", snapshotArchive),
+		fmt.Sprintf("sudo rm -f %s", snapshotArchive),
+	}
+	for _, cmd := range cmds {
+		if out, err := k.c.CombinedOutput(cmd); err != nil {
+			return errors.Wrapf(err, "running cmd: %s\n%s", cmd, out)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3s
+
+import "text/template"
+
+// k3sServiceTemplate is the systemd service of the k3s server, or of the k3s agent on worker nodes
+var k3sServiceTemplate = template.Must(template.New("k3sServiceTemplate").Parse(`
+[Unit]
+Description=Lightweight Kubernetes
+Documentation=https://k3s.io
+{{if .Docker}}Wants=docker.socket
+{{end}}After=network-online.target
+
+[Service]
+Type=notify
+ExecStart=/usr/bin/k3s {{.Command}}{{if .Flags}} {{.Flags}}{{end}}
+KillMode=process
+Delegate=yes
+LimitNOFILE=infinity
+LimitNPROC=infinity
+LimitCORE=infinity
+TasksMax=infinity
+Restart=always
+RestartSec=5s
+
+[Install]
+WantedBy=multi-user.target
+`))
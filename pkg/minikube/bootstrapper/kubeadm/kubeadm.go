@@ -32,6 +32,8 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/bootstrapper"
@@ -231,35 +233,6 @@ func (k *Bootstrapper) StartCluster(k8s config.KubernetesConfig) error {
 	return nil
 }
 
-func addAddons(files *[]assets.CopyableFile, data interface{}) error {
-	// add addons to file list
-	// custom addons
-	if err := assets.AddMinikubeDirAssets(files); err != nil {
-		return errors.Wrap(err, "adding minikube dir assets")
-	}
-	// bundled addons
-	for _, addonBundle := range assets.Addons {
-		if isEnabled, err := addonBundle.IsEnabled(); err == nil && isEnabled {
-			for _, addon := range addonBundle.Assets {
-				if addon.IsTemplate() {
-					addonFile, err := addon.Evaluate(data)
-					if err != nil {
-						return errors.Wrapf(err, "evaluate bundled addon %s asset", addon.GetAssetName())
-					}
-
-					*files = append(*files, addonFile)
-				} else {
-					*files = append(*files, addon)
-				}
-			}
-		} else if err != nil {
-			return nil
-		}
-	}
-
-	return nil
-}
-
 // waitForPods waits until the important Kubernetes pods are in running state
 func waitForPods(k8s config.KubernetesConfig, quiet bool) error {
 	// Do not wait for "k8s-app" pods in the case of CNI, as they are managed
@@ -350,6 +323,53 @@ func (k *Bootstrapper) DeleteCluster(k8s config.KubernetesConfig) error {
 	return nil
 }
 
+// JoinNode joins a worker node, reachable through the given runner, to the cluster
+func (k *Bootstrapper) JoinNode(cfg config.KubernetesConfig, nodeName string, r bootstrapper.CommandRunner) error {
+	cr, err := cruntime.New(cruntime.Config{Type: cfg.ContainerRuntime, Socket: cfg.CRISocket})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+	if err := updateNode(cfg, nodeName, r, cr); err != nil {
+		return errors.Wrap(err, "updating node")
+	}
+
+	// A node which has already joined only needs its kubelet restarted.
+	if err := r.Run(fmt.Sprintf("sudo test -f %s", constants.KubeletKubeconfigFile)); err == nil {
+		glog.Infof("%s has already joined the cluster", nodeName)
+		return r.Run("sudo systemctl daemon-reload && sudo systemctl restart kubelet")
+	}
+
+	out, err := k.c.CombinedOutput("sudo kubeadm token create --print-join-command --ttl=15m")
+	if err != nil {
+		return errors.Wrapf(err, "generating join token: %s", out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	join := strings.TrimSpace(lines[len(lines)-1])
+	if !strings.HasPrefix(join, "kubeadm join") {
+		return fmt.Errorf("unexpected join command: %q", out)
+	}
+
+	cmd := fmt.Sprintf("sudo %s --ignore-preflight-errors=all --node-name=%s --cri-socket=%s", join, nodeName, cr.SocketPath())
+	out, err = r.CombinedOutput(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "kubeadm join: %s\n%s\n", cmd, out)
+	}
+	return r.Run("sudo systemctl enable kubelet")
+}
+
+// RemoveNode removes a worker node from the cluster
+func (k *Bootstrapper) RemoveNode(cfg config.KubernetesConfig, nodeName string) error {
+	client, err := util.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "k8s client")
+	}
+	err = client.CoreV1().Nodes().Delete(nodeName, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting node %s", nodeName)
+	}
+	return nil
+}
+
 // PullImages downloads images that will be used by RestartCluster
 func (k *Bootstrapper) PullImages(k8s config.KubernetesConfig) error {
 	version, err := ParseKubernetesVersion(k8s.KubernetesVersion)
@@ -407,6 +427,11 @@ func NewKubeletConfig(k8s config.KubernetesConfig, r cruntime.Manager) (string,
 		extraOpts["feature-gates"] = kubeletFeatureArgs
 	}
 
+	// The kubelet only reads the configuration which kubeadm generates when it is told to
+	if _, ok := extraOpts["config"]; !ok && patchesKind(k8s, kubeletConfigurationKind) {
+		extraOpts["config"] = constants.KubeletConfigFile
+	}
+
 	extraFlags := convertToFlags(extraOpts)
 
 	b := bytes.Buffer{}
@@ -450,11 +475,19 @@ func (k *Bootstrapper) UpdateCluster(cfg config.KubernetesConfig) error {
 	var files []assets.CopyableFile
 	files = copyConfig(cfg, files, kubeadmCfg, kubeletCfg)
 
+	kubeletConfigCfg, err := kubeletConfig(cfg, kubeadmCfg)
+	if err != nil {
+		return errors.Wrap(err, "generating kubelet configuration")
+	}
+	if kubeletConfigCfg != "" {
+		files = append(files, assets.NewMemoryAssetTarget([]byte(kubeletConfigCfg), constants.KubeletConfigFile, "0644"))
+	}
+
 	if err := downloadBinaries(cfg, k.c); err != nil {
 		return errors.Wrap(err, "downloading binaries")
 	}
 
-	if err := addAddons(&files, assets.GenerateTemplateData(cfg)); err != nil {
+	if err := bootstrapper.AddAddons(&files, cfg); err != nil {
 		return errors.Wrap(err, "adding addons")
 	}
 
@@ -474,6 +507,46 @@ sudo systemctl start kubelet
 	return nil
 }
 
+// updateNode copies the kubelet configuration and binaries onto a worker node
+func updateNode(cfg config.KubernetesConfig, nodeName string, r bootstrapper.CommandRunner, cr cruntime.Manager) error {
+	if cfg.ShouldLoadCachedImages {
+		_, images := constants.GetKubeadmCachedImages(cfg.ImageRepository, cfg.KubernetesVersion)
+		if err := machine.LoadImages(r, images, constants.ImageCacheDir); err != nil {
+			console.Failure("Unable to load cached images: %v", err)
+		}
+	}
+
+	// Workers identify themselves by machine name, and receive the CA from kubeadm join.
+	cfg.ExtraOptions = append(util.ExtraOptionSlice{}, cfg.ExtraOptions...)
+	cfg.ExtraOptions = append(cfg.ExtraOptions,
+		util.ExtraOption{Component: Kubelet, Key: "hostname-override", Value: nodeName},
+		util.ExtraOption{Component: Kubelet, Key: "client-ca-file", Value: constants.KubeadmCACertFile})
+	kubeletCfg, err := NewKubeletConfig(cfg, cr)
+	if err != nil {
+		return errors.Wrap(err, "generating kubelet config")
+	}
+	glog.Infof("kubelet %s config for %s:\n%s", cfg.KubernetesVersion, nodeName, kubeletCfg)
+
+	if err := downloadBinaries(cfg, r); err != nil {
+		return errors.Wrap(err, "downloading binaries")
+	}
+	files := []assets.CopyableFile{
+		assets.NewMemoryAssetTarget([]byte(kubeletService), constants.KubeletServiceFile, "0640"),
+		assets.NewMemoryAssetTarget([]byte(kubeletCfg), constants.KubeletSystemdConfFile, "0640"),
+	}
+	if cfg.EnableDefaultCNI {
+		files = append(files,
+			assets.NewMemoryAssetTarget([]byte(defaultCNIConfig), constants.DefaultCNIConfigPath, "0644"),
+			assets.NewMemoryAssetTarget([]byte(defaultCNIConfig), constants.DefaultRktNetConfigPath, "0644"))
+	}
+	for _, f := range files {
+		if err := r.Copy(f); err != nil {
+			return errors.Wrapf(err, "copy")
+		}
+	}
+	return nil
+}
+
 func generateConfig(k8s config.KubernetesConfig, r cruntime.Manager) (string, error) {
 	version, err := ParseKubernetesVersion(k8s.KubernetesVersion)
 	if err != nil {
@@ -517,7 +590,7 @@ func generateConfig(k8s config.KubernetesConfig, r cruntime.Manager) (string, er
 		AdvertiseAddress:  k8s.NodeIP,
 		APIServerPort:     nodePort,
 		KubernetesVersion: k8s.KubernetesVersion,
-		EtcdDataDir:       "/data/minikube", //TODO(r2d4): change to something else persisted
+		EtcdDataDir:       util.DefaultEtcdDataDir, //TODO(r2d4): change to something else persisted
 		NodeName:          k8s.NodeName,
 		CRISocket:         r.SocketPath(),
 		ImageRepository:   k8s.ImageRepository,
@@ -547,6 +620,9 @@ func generateConfig(k8s config.KubernetesConfig, r cruntime.Manager) (string, er
 		return "", err
 	}
 
+	if k8s.ConfigPatch != "" {
+		return applyConfigPatch(b.String(), k8s.ConfigPatch, k8s.ConfigPatchType)
+	}
 	return b.String(), nil
 }
 
@@ -0,0 +1,271 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+// kubeletConfigurationKind is the kind of the kubelet configuration, in the kubeadm configuration
+const kubeletConfigurationKind = "KubeletConfiguration"
+
+const (
+	// PatchTypeStrategic merges maps, replaces lists, and honors the $patch directives of strategic merge patches
+	PatchTypeStrategic = "strategic"
+	// PatchTypeMerge is a JSON merge patch, as described in RFC 7386
+	PatchTypeMerge = "merge"
+)
+
+// schemaless describes no field to strategicpatch, which then merges maps and replaces lists, as the
+// kubeadm and kubelet configuration types declare no merge keys for their lists.
+type schemaless struct{}
+
+func (schemaless) LookupPatchMetadataForStruct(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return schemaless{}, strategicpatch.PatchMeta{}, nil
+}
+
+func (schemaless) LookupPatchMetadataForSlice(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return schemaless{}, strategicpatch.PatchMeta{}, nil
+}
+
+func (schemaless) Name() string {
+	return ""
+}
+
+// patchDocument is a document of a config patch, which patches the configuration document of the same kind
+type patchDocument struct {
+	kind       string
+	apiVersion string
+	patch      map[string]interface{}
+}
+
+// splitDocuments returns the documents of a YAML stream
+func splitDocuments(data string) ([]string, error) {
+	r := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(data)))
+	var docs []string
+	for {
+		doc, err := r.Read()
+		if err == io.EOF {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) > 0 {
+			docs = append(docs, string(doc))
+		}
+	}
+}
+
+// parseConfigPatch parses the documents of a config patch, each of which must have a kind
+func parseConfigPatch(patch string) ([]patchDocument, error) {
+	docs, err := splitDocuments(patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "splitting documents")
+	}
+	var parsed []patchDocument
+	for i, doc := range docs {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &m); err != nil {
+			return nil, errors.Wrapf(err, "document %d", i+1)
+		}
+		kind, _ := m["kind"].(string)
+		if kind == "" {
+			return nil, fmt.Errorf("document %d has no kind, which selects the configuration it patches", i+1)
+		}
+		apiVersion, _ := m["apiVersion"].(string)
+		parsed = append(parsed, patchDocument{kind: kind, apiVersion: apiVersion, patch: m})
+	}
+	return parsed, nil
+}
+
+// validatePatchType checks that a patch type is known. The empty type is strategic.
+func validatePatchType(patchType string) error {
+	if patchType != "" && patchType != PatchTypeStrategic && patchType != PatchTypeMerge {
+		return fmt.Errorf("unknown patch type %q, expected %s or %s", patchType, PatchTypeStrategic, PatchTypeMerge)
+	}
+	return nil
+}
+
+// ValidateConfigPatch checks that a config patch can be applied, before there is a configuration to apply it to
+func ValidateConfigPatch(patch, patchType string) error {
+	if err := validatePatchType(patchType); err != nil {
+		return err
+	}
+	_, err := parseConfigPatch(patch)
+	return err
+}
+
+// mergePatch applies a JSON merge patch, as described in RFC 7386
+func mergePatch(original, patch map[string]interface{}) map[string]interface{} {
+	for k, v := range patch {
+		if v == nil {
+			delete(original, k)
+			continue
+		}
+		p, ok := v.(map[string]interface{})
+		if !ok {
+			original[k] = v
+			continue
+		}
+		o, ok := original[k].(map[string]interface{})
+		if !ok {
+			o = map[string]interface{}{}
+		}
+		original[k] = mergePatch(o, p)
+	}
+	return original
+}
+
+// applyConfigPatch applies a config patch to the documents of a generated configuration. Documents which are
+// not patched are left as generated, and patch documents of other kinds are added.
+func applyConfigPatch(cfg, patch, patchType string) (string, error) {
+	if err := validatePatchType(patchType); err != nil {
+		return "", err
+	}
+	patches, err := parseConfigPatch(patch)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing config patch")
+	}
+	docs, err := splitDocuments(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "splitting configuration")
+	}
+
+	for _, p := range patches {
+		found := false
+		for i, doc := range docs {
+			m := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(doc), &m); err != nil {
+				return "", errors.Wrap(err, "parsing configuration")
+			}
+			if m["kind"] != p.kind {
+				continue
+			}
+			found = true
+			if p.apiVersion != "" && p.apiVersion != m["apiVersion"] {
+				return "", fmt.Errorf("the %s patch is for %s, but the configuration is %s", p.kind, p.apiVersion, m["apiVersion"])
+			}
+			if patchType == PatchTypeMerge {
+				m = mergePatch(m, p.patch)
+			} else {
+				m, err = strategicpatch.StrategicMergeMapPatchUsingLookupPatchMeta(m, p.patch, schemaless{})
+				if err != nil {
+					return "", errors.Wrapf(err, "patching %s", p.kind)
+				}
+			}
+			out, err := yaml.Marshal(m)
+			if err != nil {
+				return "", errors.Wrapf(err, "marshalling %s", p.kind)
+			}
+			docs[i] = string(out)
+		}
+		if found {
+			continue
+		}
+		// Complete documents add configuration which minikube does not generate, such as a KubeProxyConfiguration
+		if p.apiVersion == "" {
+			return "", fmt.Errorf("the configuration has no %s to patch, and the patch has no apiVersion to add it with", p.kind)
+		}
+		out, err := yaml.Marshal(p.patch)
+		if err != nil {
+			return "", errors.Wrapf(err, "marshalling %s", p.kind)
+		}
+		docs = append(docs, string(out))
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// patchesKind returns whether the config patch of a cluster has a document of a kind
+func patchesKind(k8s config.KubernetesConfig, kind string) bool {
+	if k8s.ConfigPatch == "" {
+		return false
+	}
+	patches, err := parseConfigPatch(k8s.ConfigPatch)
+	if err != nil {
+		return false
+	}
+	for _, p := range patches {
+		if p.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// kubeletConfig returns the kubelet configuration of a kubeadm configuration, when the config patch changes it.
+// Otherwise, the kubelet is configured by flags only, and the configuration is empty.
+func kubeletConfig(k8s config.KubernetesConfig, kubeadmCfg string) (string, error) {
+	if !patchesKind(k8s, kubeletConfigurationKind) {
+		return "", nil
+	}
+	docs, err := splitDocuments(kubeadmCfg)
+	if err != nil {
+		return "", errors.Wrap(err, "splitting configuration")
+	}
+	for _, doc := range docs {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &m); err != nil {
+			return "", errors.Wrap(err, "parsing configuration")
+		}
+		if m["kind"] == kubeletConfigurationKind {
+			return doc, nil
+		}
+	}
+	return "", fmt.Errorf("the configuration has no %s", kubeletConfigurationKind)
+}
+
+// RenderConfig returns the kubeadm configuration, with the config patch applied, and the kubelet
+// configuration, which UpdateCluster would copy, by target path
+func RenderConfig(k8s config.KubernetesConfig) (map[string]string, error) {
+	r, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Socket: k8s.CRISocket})
+	if err != nil {
+		return nil, errors.Wrap(err, "runtime")
+	}
+	kubeadmCfg, err := generateConfig(k8s, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating kubeadm cfg")
+	}
+	kubeletCfg, err := NewKubeletConfig(k8s, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating kubelet config")
+	}
+	files := map[string]string{
+		constants.KubeadmConfigFile:      kubeadmCfg,
+		constants.KubeletSystemdConfFile: kubeletCfg,
+	}
+	kubeletConfigCfg, err := kubeletConfig(k8s, kubeadmCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "kubelet configuration")
+	}
+	if kubeletConfigCfg != "" {
+		files[constants.KubeletConfigFile] = kubeletConfigCfg
+	}
+	return files, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+const generated = `apiVersion: kubeadm.k8s.io/v1beta1
+kind: ClusterConfiguration
+apiServer:
+  extraArgs:
+    enable-admission-plugins: "NamespaceLifecycle"
+    v: "2"
+kubernetesVersion: v1.14.1
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+imageGCHighThresholdPercent: 100
+evictionHard:
+  nodefs.available: "0%"
+`
+
+func TestApplyConfigPatch(t *testing.T) {
+	tests := []struct {
+		description string
+		patch       string
+		patchType   string
+		expected    string
+		shouldErr   string
+	}{
+		{
+			description: "strategic",
+			patch: `kind: ClusterConfiguration
+apiServer:
+  extraArgs:
+    audit-policy-file: /etc/kubernetes/audit.yaml
+    v: null
+  extraVolumes:
+  - name: audit
+    hostPath: /etc/kubernetes/audit.yaml
+    mountPath: /etc/kubernetes/audit.yaml
+`,
+			expected: `apiServer:
+  extraArgs:
+    audit-policy-file: /etc/kubernetes/audit.yaml
+    enable-admission-plugins: NamespaceLifecycle
+  extraVolumes:
+  - hostPath: /etc/kubernetes/audit.yaml
+    mountPath: /etc/kubernetes/audit.yaml
+    name: audit
+apiVersion: kubeadm.k8s.io/v1beta1
+kind: ClusterConfiguration
+kubernetesVersion: v1.14.1
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+imageGCHighThresholdPercent: 100
+evictionHard:
+  nodefs.available: "0%"
+`,
+		},
+		{
+			description: "strategic replace directive",
+			patch: `kind: KubeletConfiguration
+evictionHard:
+  $patch: replace
+  memory.available: 100Mi
+`,
+			expected: `apiVersion: kubeadm.k8s.io/v1beta1
+kind: ClusterConfiguration
+apiServer:
+  extraArgs:
+    enable-admission-plugins: "NamespaceLifecycle"
+    v: "2"
+kubernetesVersion: v1.14.1
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+evictionHard:
+  memory.available: 100Mi
+imageGCHighThresholdPercent: 100
+kind: KubeletConfiguration
+`,
+		},
+		{
+			description: "merge adds a document",
+			patchType:   PatchTypeMerge,
+			patch: `apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+evictionHard: null
+maxPods: 20
+---
+apiVersion: kubeproxy.config.k8s.io/v1alpha1
+kind: KubeProxyConfiguration
+mode: ipvs
+`,
+			expected: `apiVersion: kubeadm.k8s.io/v1beta1
+kind: ClusterConfiguration
+apiServer:
+  extraArgs:
+    enable-admission-plugins: "NamespaceLifecycle"
+    v: "2"
+kubernetesVersion: v1.14.1
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+imageGCHighThresholdPercent: 100
+kind: KubeletConfiguration
+maxPods: 20
+---
+apiVersion: kubeproxy.config.k8s.io/v1alpha1
+kind: KubeProxyConfiguration
+mode: ipvs
+`,
+		},
+		{
+			description: "no kind",
+			patch:       "maxPods: 20\n",
+			shouldErr:   "document 1 has no kind",
+		},
+		{
+			description: "other api version",
+			patch:       "apiVersion: kubeadm.k8s.io/v1alpha3\nkind: ClusterConfiguration\n",
+			shouldErr:   "the ClusterConfiguration patch is for kubeadm.k8s.io/v1alpha3, but the configuration is kubeadm.k8s.io/v1beta1",
+		},
+		{
+			description: "unknown document without api version",
+			patch:       "kind: KubeProxyConfiguration\nmode: ipvs\n",
+			shouldErr:   "the configuration has no KubeProxyConfiguration to patch",
+		},
+		{
+			description: "unknown type",
+			patch:       "kind: ClusterConfiguration\n",
+			patchType:   "json",
+			shouldErr:   `unknown patch type "json"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual, err := applyConfigPatch(generated, test.patch, test.patchType)
+			if test.shouldErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.shouldErr) {
+					t.Fatalf("expected an error containing %q, got %v", test.shouldErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != test.expected {
+				t.Errorf("Actual:\n%s\nExpected:\n%s", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenderConfigKubeletConfiguration(t *testing.T) {
+	k8s := config.KubernetesConfig{
+		NodeIP:            "192.168.1.100",
+		KubernetesVersion: "v1.14.1",
+		NodeName:          "minikube",
+		ContainerRuntime:  "docker",
+	}
+	files, err := RenderConfig(k8s)
+	if err != nil {
+		t.Fatalf("RenderConfig: %v", err)
+	}
+	if _, ok := files[constants.KubeletConfigFile]; ok {
+		t.Errorf("unexpected kubelet configuration without a patch: %v", files)
+	}
+
+	k8s.ConfigPatch = "kind: KubeletConfiguration\nmaxPods: 20\n"
+	files, err = RenderConfig(k8s)
+	if err != nil {
+		t.Fatalf("RenderConfig: %v", err)
+	}
+	if !strings.Contains(files[constants.KubeletConfigFile], "maxPods: 20\n") {
+		t.Errorf("expected the patched kubelet configuration, got: %q", files[constants.KubeletConfigFile])
+	}
+	if !strings.Contains(files[constants.KubeletSystemdConfFile], "--config="+constants.KubeletConfigFile) {
+		t.Errorf("expected the kubelet to read its configuration, got: %s", files[constants.KubeletSystemdConfFile])
+	}
+	if !strings.Contains(files[constants.KubeadmConfigFile], "maxPods: 20\n") {
+		t.Errorf("expected the patched kubeadm configuration, got: %s", files[constants.KubeadmConfigFile])
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestSaveSnapshot(t *testing.T) {
+	archive := []byte("\x1f\x8b fake archive")
+	f := bootstrapper.NewFakeCommandRunner()
+	f.SetCommandToOutput(map[string]string{
+		"sudo tar -C / -czf /tmp/minikube-snapshot.tar.gz data/minikube var/lib/minikube/certs": "",
+		"sudo base64 /tmp/minikube-snapshot.tar.gz":                                             base64.StdEncoding.EncodeToString(archive) + "\n",
+		"sudo rm -f /tmp/minikube-snapshot.tar.gz":                                              "",
+	})
+	k := &Bootstrapper{c: f}
+	got, err := k.SaveSnapshot(config.KubernetesConfig{})
+	if err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if string(got) != string(archive) {
+		t.Errorf("SaveSnapshot() = %q, want %q", got, archive)
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	archive := []byte("\x1f\x8b fake archive")
+	f := bootstrapper.NewFakeCommandRunner()
+	f.SetCommandToOutput(map[string]string{
+		"sudo systemctl stop kubelet": "",
+		`docker ps --filter="name=k8s_" --filter="status=paused" --format="{{.ID}}"`:  "",
+		`docker ps --filter="name=k8s_" --filter="status=running" --format="{{.ID}}"`: "abc\ndef\n",
+		"docker stop abc def":                              "",
+		"sudo rm -rf /data/minikube":                       "",
+		"sudo tar -C / -xzf /tmp/minikube-snapshot.tar.gz": "",
+		"sudo rm -f /tmp/minikube-snapshot.tar.gz":         "",
+		"sudo systemctl start kubelet":                     "",
+	})
+	k := &Bootstrapper{c: f}
+	if err := k.RestoreSnapshot(config.KubernetesConfig{ContainerRuntime: "docker"}, archive); err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	// MemoryAssets have no asset name, so the fake runner stores them under ""
+	got, err := f.GetFileToContents("")
+	if err != nil {
+		t.Fatalf("archive was not copied: %v", err)
+	}
+	if got != string(archive) {
+		t.Errorf("copied archive = %q, want %q", got, archive)
+	}
+}
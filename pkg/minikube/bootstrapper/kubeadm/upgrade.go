@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/console"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/util"
+)
+
+// controlPlaneComponents are the static pods which kubeadm upgrades, and which run the Kubernetes version.
+// etcd is versioned on its own.
+var controlPlaneComponents = []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+
+// UpgradeCluster upgrades the control plane with kubeadm, then restarts the kubelet, which UpdateCluster replaced
+func (k *Bootstrapper) UpgradeCluster(k8s config.KubernetesConfig) error {
+	if _, err := ParseKubernetesVersion(k8s.KubernetesVersion); err != nil {
+		return errors.Wrap(err, "parsing kubernetes version")
+	}
+
+	// kubeadm rolls the static pods back by itself if any of them fails to start
+	cmd := fmt.Sprintf("sudo kubeadm upgrade apply %s --config %s --yes", k8s.KubernetesVersion, constants.KubeadmConfigFile)
+	if out, err := k.c.CombinedOutput(cmd); err != nil {
+		return errors.Wrapf(err, "kubeadm upgrade: %s\n%s", cmd, out)
+	}
+
+	// The running kubelet is the previous version until it restarts. kubeadm wrote its new configuration.
+	if err := k.c.Run("sudo systemctl restart kubelet"); err != nil {
+		return errors.Wrap(err, "restarting kubelet")
+	}
+
+	if err := waitForPods(k8s, false); err != nil {
+		return errors.Wrap(err, "wait")
+	}
+
+	console.OutStyle("verifying", "Verifying the version of the Kubernetes components ...")
+	// The node reports the version of the kubelet a few seconds after it started.
+	if err := util.RetryAfter(10, func() error { return verifyVersions(k8s) }, 3*time.Second); err != nil {
+		return errors.Wrap(err, "verifying versions")
+	}
+	return nil
+}
+
+// verifyVersions checks that the api server, the control plane pods and the kubelet run the version of the cluster
+func verifyVersions(k8s config.KubernetesConfig) error {
+	client, err := util.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "k8s client")
+	}
+	versions, err := bootstrapper.ComponentVersions(client, k8s.NodeName)
+	if err != nil {
+		return err
+	}
+	pods, err := client.CoreV1().Pods("kube-system").List(metav1.ListOptions{LabelSelector: "tier=control-plane"})
+	if err != nil {
+		return errors.Wrap(err, "listing control plane pods")
+	}
+	for c, v := range controlPlaneVersions(pods.Items) {
+		versions[c] = v
+	}
+	return bootstrapper.CheckVersions(k8s.KubernetesVersion, versions)
+}
+
+// controlPlaneVersions returns the versions of the control plane components, from the tag of their image
+func controlPlaneVersions(pods []v1.Pod) map[string]string {
+	versions := map[string]string{}
+	for _, p := range pods {
+		component := p.Labels["component"]
+		for _, c := range controlPlaneComponents {
+			if c != component || len(p.Spec.Containers) == 0 {
+				continue
+			}
+			image := p.Spec.Containers[0].Image
+			versions[component] = image[strings.LastIndex(image, ":")+1:]
+		}
+	}
+	return versions
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func staticPod(component, image string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"component": component, "tier": "control-plane"}},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: component, Image: image}}},
+	}
+}
+
+func TestControlPlaneVersions(t *testing.T) {
+	pods := []v1.Pod{
+		staticPod("etcd", "k8s.gcr.io/etcd:3.3.10"),
+		staticPod("kube-apiserver", "k8s.gcr.io/kube-apiserver:v1.14.1"),
+		staticPod("kube-controller-manager", "localhost:5000/kube-controller-manager:v1.14.1"),
+		staticPod("kube-scheduler", "k8s.gcr.io/kube-scheduler:v1.13.5"),
+	}
+	expected := map[string]string{
+		"kube-apiserver":          "v1.14.1",
+		"kube-controller-manager": "v1.14.1",
+		"kube-scheduler":          "v1.13.5",
+	}
+	if got := controlPlaneVersions(pods); !reflect.DeepEqual(got, expected) {
+		t.Errorf("controlPlaneVersions() = %v, want %v", got, expected)
+	}
+}
+
+func TestUpgradeClusterFailure(t *testing.T) {
+	// the kubeadm upgrade command is unknown to the fake runner, so it fails
+	f := bootstrapper.NewFakeCommandRunner()
+	k := &Bootstrapper{c: f}
+	err := k.UpgradeCluster(config.KubernetesConfig{KubernetesVersion: "v1.14.1"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	cmd := "sudo kubeadm upgrade apply v1.14.1 --config /var/lib/kubeadm.yaml --yes"
+	if !strings.Contains(err.Error(), cmd) {
+		t.Errorf("expected %q in the error, got: %v", cmd, err)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ComponentVersions returns the Kubernetes versions which the api server, and the kubelet of a node, run
+func ComponentVersions(client kubernetes.Interface, nodeName string) (map[string]string, error) {
+	v, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "api server version")
+	}
+	node, err := client.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting node %s", nodeName)
+	}
+	return map[string]string{
+		"apiserver": v.GitVersion,
+		"kubelet":   node.Status.NodeInfo.KubeletVersion,
+	}, nil
+}
+
+// CheckVersions returns an error naming the components which do not run a Kubernetes version.
+// Builds of the version, such as v1.14.1-k3s.4, are considered to run it.
+func CheckVersions(version string, components map[string]string) error {
+	want, err := semver.ParseTolerant(version)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s", version)
+	}
+	var names []string
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stale []string
+	for _, name := range names {
+		got, err := semver.ParseTolerant(components[name])
+		if err != nil || got.Major != want.Major || got.Minor != want.Minor || got.Patch != want.Patch {
+			stale = append(stale, fmt.Sprintf("%s runs %q", name, components[name]))
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("expected Kubernetes %s, but %s", version, strings.Join(stale, ", "))
+	}
+	return nil
+}
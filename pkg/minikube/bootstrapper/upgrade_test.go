@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapper
+
+import (
+	"testing"
+)
+
+func TestCheckVersions(t *testing.T) {
+	tests := []struct {
+		description string
+		components  map[string]string
+		expected    string
+	}{
+		{
+			description: "upgraded",
+			components:  map[string]string{"apiserver": "v1.14.1", "kubelet": "v1.14.1"},
+		},
+		{
+			description: "k3s build",
+			components:  map[string]string{"apiserver": "v1.14.1-k3s.4", "kubelet": "v1.14.1-k3s.4"},
+		},
+		{
+			description: "stale kubelet and scheduler",
+			components:  map[string]string{"apiserver": "v1.14.1", "kubelet": "v1.13.5", "kube-scheduler": "v1.13.5"},
+			expected:    `expected Kubernetes v1.14.1, but kube-scheduler runs "v1.13.5", kubelet runs "v1.13.5"`,
+		},
+		{
+			description: "unknown version",
+			components:  map[string]string{"apiserver": "latest"},
+			expected:    `expected Kubernetes v1.14.1, but apiserver runs "latest"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := CheckVersions("v1.14.1", test.components)
+			if test.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.expected {
+				t.Errorf("expected error %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
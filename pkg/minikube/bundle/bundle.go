@@ -0,0 +1,221 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle reads and writes archives of the files minikube needs to start a cluster offline
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// manifestName is the name of the manifest, which is the first entry of a bundle
+const manifestName = "manifest.json"
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	MinikubeVersion   string
+	KubernetesVersion string
+	Files             []File
+}
+
+// File is a file of the minikube home directory included in a bundle
+type File struct {
+	// Path is relative to the minikube home directory, with forward slashes
+	Path   string
+	Size   int64
+	SHA256 string
+}
+
+// digest returns the size and hex encoded sha256 digest of a file
+func digest(p string) (int64, string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// validPath returns an error if a path from a bundle would be written outside of the minikube home directory
+func validPath(p string) error {
+	if p == "" || path.IsAbs(p) || strings.Contains(p, `\`) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("invalid path in bundle: %q", p)
+	}
+	return nil
+}
+
+// Write writes a bundle of files of the home directory, given as paths relative to it.
+// The files of the manifest are filled in with the digests of the files.
+func Write(w io.Writer, home string, m *Manifest, files []string) error {
+	m.Files = nil
+	for _, f := range files {
+		p := filepath.ToSlash(f)
+		if err := validPath(p); err != nil {
+			return err
+		}
+		size, sum, err := digest(filepath.Join(home, f))
+		if err != nil {
+			return errors.Wrapf(err, "digest %s", f)
+		}
+		m.Files = append(m.Files, File{Path: p, Size: size, SHA256: sum})
+	}
+	data, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "marshal manifest")
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0644, Size: int64(len(data))}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+	for _, f := range m.Files {
+		if err := writeFile(tw, filepath.Join(home, filepath.FromSlash(f.Path)), f); err != nil {
+			return errors.Wrapf(err, "writing %s", f.Path)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// writeFile writes a file as an entry of a bundle
+func writeFile(tw *tar.Writer, p string, f File) error {
+	r, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	fi, err := r.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: f.Path, Mode: int64(fi.Mode().Perm()), Size: f.Size, ModTime: fi.ModTime()}); err != nil {
+		return err
+	}
+	// The size is fixed by the manifest, which guards against the file changing since its digest was computed
+	_, err = io.CopyN(tw, r, f.Size)
+	return err
+}
+
+// Read verifies the files of a bundle against its manifest, and unpacks them into the home directory.
+// Each file is only moved into place once verified, so a corrupt bundle never leaves partial files behind.
+func Read(r io.Reader, home string) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "not a bundle")
+	}
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading manifest")
+	}
+	if hdr.Name != manifestName {
+		return nil, fmt.Errorf("not a bundle: first entry is %q, expected %q", hdr.Name, manifestName)
+	}
+	var m Manifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, errors.Wrap(err, "parsing manifest")
+	}
+	expected := map[string]File{}
+	for _, f := range m.Files {
+		if err := validPath(f.Path); err != nil {
+			return nil, err
+		}
+		expected[f.Path] = f
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading bundle")
+		}
+		f, ok := expected[hdr.Name]
+		if !ok {
+			return nil, fmt.Errorf("%q is not in the manifest", hdr.Name)
+		}
+		if err := readFile(tr, filepath.Join(home, filepath.FromSlash(f.Path)), os.FileMode(hdr.Mode).Perm(), f); err != nil {
+			return nil, errors.Wrapf(err, "unpacking %s", f.Path)
+		}
+		delete(expected, hdr.Name)
+	}
+	for p := range expected {
+		return nil, fmt.Errorf("%q is missing from the bundle", p)
+	}
+	return &m, nil
+}
+
+// readFile unpacks and verifies an entry of a bundle
+func readFile(r io.Reader, dst string, mode os.FileMode, f File) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tf, err := ioutil.TempFile(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tf, h), r)
+	if err != nil {
+		tf.Close()
+		return err
+	}
+	if err := tf.Close(); err != nil {
+		return err
+	}
+	if n != f.Size {
+		return fmt.Errorf("size mismatch: expected %d, got %d", f.Size, n)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != f.SHA256 {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", f.SHA256, sum)
+	}
+	if err := os.Chmod(tf.Name(), mode); err != nil {
+		return err
+	}
+	glog.Infof("unpacked %s", dst)
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Rename(tf.Name(), dst)
+}
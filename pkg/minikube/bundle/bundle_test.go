@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testFiles = map[string]string{
+	"cache/iso/minikube-v1.0.0.iso":     "iso",
+	"cache/v1.14.1/kubeadm":             "kubeadm",
+	"cache/images/k8s.gcr.io/pause_3.1": "pause",
+}
+
+func tempHome(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "minikube-bundle-test")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	return dir
+}
+
+func TestWriteRead(t *testing.T) {
+	src := tempHome(t)
+	defer os.RemoveAll(src)
+	var files []string
+	for name, content := range testFiles {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0755); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		files = append(files, filepath.FromSlash(name))
+	}
+
+	var b bytes.Buffer
+	if err := Write(&b, src, &Manifest{KubernetesVersion: "v1.14.1"}, files); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	dst := tempHome(t)
+	defer os.RemoveAll(dst)
+	m, err := Read(&b, dst)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if m.KubernetesVersion != "v1.14.1" || len(m.Files) != len(testFiles) {
+		t.Errorf("Read() manifest = %+v", m)
+	}
+	for name, content := range testFiles {
+		p := filepath.Join(dst, filepath.FromSlash(name))
+		got, err := ioutil.ReadFile(p)
+		if err != nil {
+			t.Errorf("%s was not unpacked: %v", name, err)
+			continue
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", name, got, content)
+		}
+		if fi, err := os.Stat(p); err == nil && fi.Mode().Perm()&0100 == 0 {
+			t.Errorf("%s lost its permissions: %v", name, fi.Mode())
+		}
+	}
+}
+
+// writeBundle writes a bundle with an arbitrary manifest and entries
+func writeBundle(t *testing.T, m Manifest, entries map[string]string) *bytes.Buffer {
+	var b bytes.Buffer
+	gw := gzip.NewWriter(&b)
+	tw := tar.NewWriter(gw)
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	write := func(name string, content []byte) {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("header: %v", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	write(manifestName, data)
+	for name, content := range entries {
+		write(name, []byte(content))
+	}
+	tw.Close()
+	gw.Close()
+	return &b
+}
+
+func TestReadInvalid(t *testing.T) {
+	var tests = []struct {
+		description string
+		manifest    Manifest
+		entries     map[string]string
+	}{
+		{
+			description: "digest mismatch",
+			manifest:    Manifest{Files: []File{{Path: "cache/a", Size: 1, SHA256: "0000"}}},
+			entries:     map[string]string{"cache/a": "a"},
+		},
+		{
+			description: "path traversal",
+			manifest:    Manifest{Files: []File{{Path: "../a", Size: 1}}},
+			entries:     map[string]string{"../a": "a"},
+		},
+		{
+			description: "absolute path",
+			manifest:    Manifest{Files: []File{{Path: "/etc/a", Size: 1}}},
+			entries:     map[string]string{"/etc/a": "a"},
+		},
+		{
+			description: "unexpected entry",
+			manifest:    Manifest{},
+			entries:     map[string]string{"cache/a": "a"},
+		},
+		{
+			description: "missing entry",
+			manifest:    Manifest{Files: []File{{Path: "cache/a", Size: 1}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			dst := tempHome(t)
+			defer os.RemoveAll(dst)
+			if _, err := Read(writeBundle(t, tc.manifest, tc.entries), dst); err == nil {
+				t.Errorf("Read() succeeded, want error")
+			}
+			if _, err := os.Stat(filepath.Join(dst, "cache", "a")); !os.IsNotExist(err) {
+				t.Errorf("an invalid file was unpacked")
+			}
+		})
+	}
+}
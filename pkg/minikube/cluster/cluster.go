@@ -68,11 +68,20 @@ func CacheISO(config cfg.MachineConfig) error {
 	return nil
 }
 
+// NodeMachineConfig returns the config of the VM of a worker node: the config of the primary, with its own name,
+// and without the UUID of the primary, so that the VM does not get the MAC address and IP of the primary.
+func NodeMachineConfig(config cfg.MachineConfig, name string) cfg.MachineConfig {
+	config.Name = name
+	config.UUID = ""
+	return config
+}
+
 // StartHost starts a host VM.
 func StartHost(api libmachine.API, config cfg.MachineConfig) (*host.Host, error) {
-	exists, err := api.Exists(cfg.GetMachineName())
+	name := config.MachineName()
+	exists, err := api.Exists(name)
 	if err != nil {
-		return nil, errors.Wrapf(err, "machine name: %s", cfg.GetMachineName())
+		return nil, errors.Wrapf(err, "machine name: %s", name)
 	}
 	if !exists {
 		glog.Infoln("Machine does not exist... provisioning new machine")
@@ -82,7 +91,7 @@ func StartHost(api libmachine.API, config cfg.MachineConfig) (*host.Host, error)
 
 	glog.Infoln("Skipping create...Using existing machine configuration")
 
-	h, err := api.Load(cfg.GetMachineName())
+	h, err := api.Load(name)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error loading existing host. Please try running [minikube delete], then run [minikube start] again.")
 	}
@@ -90,11 +99,11 @@ func StartHost(api libmachine.API, config cfg.MachineConfig) (*host.Host, error)
 	if h.Driver.DriverName() != config.VMDriver {
 		console.Out("\n")
 		console.Warning("Ignoring --vm-driver=%s, as the existing %q VM was created using the %s driver.",
-			config.VMDriver, cfg.GetMachineName(), h.Driver.DriverName())
+			config.VMDriver, name, h.Driver.DriverName())
 		console.Warning("To switch drivers, you may create a new VM using `minikube start -p <name> --vm-driver=%s`", config.VMDriver)
-		console.Warning("Alternatively, you may delete the existing VM using `minikube delete -p %s`", cfg.GetMachineName())
+		console.Warning("Alternatively, you may delete the existing VM using `minikube delete -p %s`", name)
 		console.Out("\n")
-	} else if exists && cfg.GetMachineName() == constants.DefaultMachineName {
+	} else if exists && name == constants.DefaultMachineName {
 		console.OutStyle("tip", "Tip: Use 'minikube start -p <name>' to create a new cluster, or 'minikube delete' to delete this one.")
 	}
 
@@ -105,9 +114,9 @@ func StartHost(api libmachine.API, config cfg.MachineConfig) (*host.Host, error)
 	}
 
 	if s == state.Running {
-		console.OutStyle("running", "Re-using the currently running %s VM for %q ...", h.Driver.DriverName(), cfg.GetMachineName())
+		console.OutStyle("running", "Re-using the currently running %s VM for %q ...", h.Driver.DriverName(), name)
 	} else {
-		console.OutStyle("restarting", "Restarting existing %s VM for %q ...", h.Driver.DriverName(), cfg.GetMachineName())
+		console.OutStyle("restarting", "Restarting existing %s VM for %q ...", h.Driver.DriverName(), name)
 		if err := h.Driver.Start(); err != nil {
 			return nil, errors.Wrap(err, "start")
 		}
@@ -164,32 +173,32 @@ func trySSHPowerOff(h *host.Host) {
 		return
 	}
 
-	console.OutStyle("shutdown", "Powering off %q via SSH ...", cfg.GetMachineName())
+	console.OutStyle("shutdown", "Powering off %q via SSH ...", h.Name)
 	out, err := h.RunSSHCommand("sudo poweroff")
 	// poweroff always results in an error, since the host disconnects.
 	glog.Infof("poweroff result: out=%s, err=%v", out, err)
 }
 
 // StopHost stops the host VM, saving state to disk.
-func StopHost(api libmachine.API) error {
-	host, err := api.Load(cfg.GetMachineName())
+func StopHost(api libmachine.API, machineName string) error {
+	host, err := api.Load(machineName)
 	if err != nil {
 		return errors.Wrapf(err, "load")
 	}
-	console.OutStyle("stopping", "Stopping %q in %s ...", cfg.GetMachineName(), host.DriverName)
+	console.OutStyle("stopping", "Stopping %q in %s ...", machineName, host.DriverName)
 	if err := host.Stop(); err != nil {
 		alreadyInStateError, ok := err.(mcnerror.ErrHostAlreadyInState)
 		if ok && alreadyInStateError.State == state.Stopped {
 			return nil
 		}
-		return &util.RetriableError{Err: errors.Wrapf(err, "Stop: %s", cfg.GetMachineName())}
+		return &util.RetriableError{Err: errors.Wrapf(err, "Stop: %s", machineName)}
 	}
 	return nil
 }
 
 // DeleteHost deletes the host VM.
-func DeleteHost(api libmachine.API) error {
-	host, err := api.Load(cfg.GetMachineName())
+func DeleteHost(api libmachine.API, machineName string) error {
+	host, err := api.Load(machineName)
 	if err != nil {
 		return errors.Wrap(err, "load")
 	}
@@ -198,27 +207,27 @@ func DeleteHost(api libmachine.API) error {
 		trySSHPowerOff(host)
 	}
 
-	console.OutStyle("deleting-host", "Deleting %q from %s ...", cfg.GetMachineName(), host.DriverName)
+	console.OutStyle("deleting-host", "Deleting %q from %s ...", machineName, host.DriverName)
 	if err := host.Driver.Remove(); err != nil {
 		return errors.Wrap(err, "host remove")
 	}
-	if err := api.Remove(cfg.GetMachineName()); err != nil {
+	if err := api.Remove(machineName); err != nil {
 		return errors.Wrap(err, "api remove")
 	}
 	return nil
 }
 
 // GetHostStatus gets the status of the host VM.
-func GetHostStatus(api libmachine.API) (string, error) {
-	exists, err := api.Exists(cfg.GetMachineName())
+func GetHostStatus(api libmachine.API, machineName string) (string, error) {
+	exists, err := api.Exists(machineName)
 	if err != nil {
-		return "", errors.Wrapf(err, "%s exists", cfg.GetMachineName())
+		return "", errors.Wrapf(err, "%s exists", machineName)
 	}
 	if !exists {
 		return state.None.String(), nil
 	}
 
-	host, err := api.Load(cfg.GetMachineName())
+	host, err := api.Load(machineName)
 	if err != nil {
 		return "", errors.Wrapf(err, "load")
 	}
@@ -443,7 +452,7 @@ func CreateSSHShell(api libmachine.API, args []string) error {
 // EnsureMinikubeRunningOrExit checks that minikube has a status available and that
 // the status is `Running`, otherwise it will exit
 func EnsureMinikubeRunningOrExit(api libmachine.API, exitStatus int) {
-	s, err := GetHostStatus(api)
+	s, err := GetHostStatus(api, cfg.GetMachineName())
 	if err != nil {
 		exit.WithError("Error getting machine status", err)
 	}
@@ -79,6 +79,39 @@ func TestCreateHost(t *testing.T) {
 	}
 }
 
+func TestStartHostWithMachineName(t *testing.T) {
+	named := defaultMachineConfig
+	named.Name = "minikube-m02"
+	primary := defaultMachineConfig
+	primary.UUID = "0F1A3E70-5C4D-4B1B-8A1B-3F7E0C2D9A11"
+	tcs := []struct {
+		description string
+		config      config.MachineConfig
+	}{
+		{"name", named},
+		{"worker node", NodeMachineConfig(primary, "minikube-m02")},
+	}
+	for _, tc := range tcs {
+		api := tests.NewMockAPI()
+		mc := tc.config
+
+		h, err := StartHost(api, mc)
+		if err != nil {
+			t.Fatalf("%s: Error starting host: %v", tc.description, err)
+		}
+		if h.Name != "minikube-m02" {
+			t.Fatalf("%s: Machine created with incorrect name: %s", tc.description, h.Name)
+		}
+		if exists, _ := api.Exists(config.GetMachineName()); exists {
+			t.Fatalf("%s: Machine for the profile should not have been created.", tc.description)
+		}
+		// hyperkit derives the MAC address, and so the IP, of a VM from its UUID
+		if mc.UUID != "" {
+			t.Errorf("%s: expected the VM to get its own UUID, got the one of the primary: %s", tc.description, mc.UUID)
+		}
+	}
+}
+
 func TestStartHostExists(t *testing.T) {
 	api := tests.NewMockAPI()
 	// Create an initial host.
@@ -207,7 +240,7 @@ func TestStartHostConfig(t *testing.T) {
 
 func TestStopHostError(t *testing.T) {
 	api := tests.NewMockAPI()
-	if err := StopHost(api); err == nil {
+	if err := StopHost(api, config.GetMachineName()); err == nil {
 		t.Fatal("An error should be thrown when stopping non-existing machine.")
 	}
 }
@@ -215,7 +248,7 @@ func TestStopHostError(t *testing.T) {
 func TestStopHost(t *testing.T) {
 	api := tests.NewMockAPI()
 	h, _ := createHost(api, defaultMachineConfig)
-	if err := StopHost(api); err != nil {
+	if err := StopHost(api, config.GetMachineName()); err != nil {
 		t.Fatal("An error should be thrown when stopping non-existing machine.")
 	}
 	if s, _ := h.Driver.GetState(); s != state.Stopped {
@@ -227,7 +260,7 @@ func TestDeleteHost(t *testing.T) {
 	api := tests.NewMockAPI()
 	createHost(api, defaultMachineConfig)
 
-	if err := DeleteHost(api); err != nil {
+	if err := DeleteHost(api, config.GetMachineName()); err != nil {
 		t.Fatalf("Unexpected error deleting host: %v", err)
 	}
 }
@@ -240,7 +273,7 @@ func TestDeleteHostErrorDeletingVM(t *testing.T) {
 
 	h.Driver = d
 
-	if err := DeleteHost(api); err == nil {
+	if err := DeleteHost(api, config.GetMachineName()); err == nil {
 		t.Fatal("Expected error deleting host.")
 	}
 }
@@ -250,7 +283,7 @@ func TestDeleteHostErrorDeletingFiles(t *testing.T) {
 	api.RemoveError = true
 	createHost(api, defaultMachineConfig)
 
-	if err := DeleteHost(api); err == nil {
+	if err := DeleteHost(api, config.GetMachineName()); err == nil {
 		t.Fatal("Expected error deleting host.")
 	}
 }
@@ -259,7 +292,7 @@ func TestGetHostStatus(t *testing.T) {
 	api := tests.NewMockAPI()
 
 	checkState := func(expected string) {
-		s, err := GetHostStatus(api)
+		s, err := GetHostStatus(api, config.GetMachineName())
 		if err != nil {
 			t.Fatalf("Unexpected error getting status: %v", err)
 		}
@@ -273,7 +306,7 @@ func TestGetHostStatus(t *testing.T) {
 	createHost(api, defaultMachineConfig)
 	checkState(state.Running.String())
 
-	StopHost(api)
+	StopHost(api, config.GetMachineName())
 	checkState(state.Stopped.String())
 }
 
@@ -0,0 +1,60 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+// Pause stops kubelet and freezes all Kubernetes containers, returning the IDs of the paused containers
+func Pause(cr cruntime.Manager, r cruntime.CommandRunner) ([]string, error) {
+	// kubelet must be stopped first, or it will restart the containers it considers unhealthy
+	if err := r.Run("sudo systemctl stop kubelet"); err != nil {
+		return nil, errors.Wrap(err, "stopping kubelet")
+	}
+	ids, err := cr.ListKubernetesContainers(cruntime.ContainerRunning)
+	if err != nil {
+		return nil, errors.Wrap(err, "list running")
+	}
+	if len(ids) == 0 {
+		glog.Warningf("no running containers to pause")
+		return ids, nil
+	}
+	if err := cr.PauseContainers(ids); err != nil {
+		return ids, errors.Wrap(err, "pausing containers")
+	}
+	return ids, nil
+}
+
+// Unpause resumes all paused Kubernetes containers and starts kubelet, returning the IDs of the resumed containers
+func Unpause(cr cruntime.Manager, r cruntime.CommandRunner) ([]string, error) {
+	ids, err := cr.ListKubernetesContainers(cruntime.ContainerPaused)
+	if err != nil {
+		return nil, errors.Wrap(err, "list paused")
+	}
+	if len(ids) == 0 {
+		glog.Warningf("no paused containers to unpause")
+	} else if err := cr.UnpauseContainers(ids); err != nil {
+		return ids, errors.Wrap(err, "unpausing containers")
+	}
+	if err := r.Run("sudo systemctl start kubelet"); err != nil {
+		return ids, errors.Wrap(err, "starting kubelet")
+	}
+	return ids, nil
+}
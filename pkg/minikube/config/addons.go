@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+// SetAddonValues sets values of an addon, keeping the ones which are not set again
+func (k *KubernetesConfig) SetAddonValues(addon string, values map[string]string) {
+	if k.AddonValues == nil {
+		k.AddonValues = map[string]map[string]string{}
+	}
+	if k.AddonValues[addon] == nil {
+		k.AddonValues[addon] = map[string]string{}
+	}
+	for key, v := range values {
+		k.AddonValues[addon][key] = v
+	}
+}
+
+// SetAddonImages sets the image overrides of an addon, keeping the ones which are not set again.
+// An empty registry keeps the current one.
+func (k *KubernetesConfig) SetAddonImages(addon string, registry string, images map[string]string) {
+	if k.AddonImages == nil {
+		k.AddonImages = map[string]AddonImages{}
+	}
+	o := k.AddonImages[addon]
+	if registry != "" {
+		o.Registry = registry
+	}
+	if len(images) > 0 && o.Images == nil {
+		o.Images = map[string]string{}
+	}
+	for orig, ref := range images {
+		o.Images[orig] = ref
+	}
+	k.AddonImages[addon] = o
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetAddonValues(t *testing.T) {
+	k := KubernetesConfig{}
+	k.SetAddonValues("ingress", map[string]string{"extraArgs": "--v=2"})
+	k.SetAddonValues("registry", map[string]string{"storageSize": "1Gi"})
+	k.SetAddonValues("ingress", map[string]string{"other": "value"})
+
+	expected := map[string]map[string]string{
+		"ingress":  {"extraArgs": "--v=2", "other": "value"},
+		"registry": {"storageSize": "1Gi"},
+	}
+	if !reflect.DeepEqual(k.AddonValues, expected) {
+		t.Errorf("expected %v, got %v", expected, k.AddonValues)
+	}
+}
+
+func TestSetAddonImages(t *testing.T) {
+	k := KubernetesConfig{}
+	k.SetAddonImages("ingress", "mirror.example.com", nil)
+	k.SetAddonImages("ingress", "", map[string]string{"nginx": "mirror.example.com/nginx:1.15"})
+	k.SetAddonImages("registry", "", map[string]string{"registry.hub.docker.com/library/registry:2.6.1": "registry:2"})
+
+	expected := map[string]AddonImages{
+		"ingress": {
+			Registry: "mirror.example.com",
+			Images:   map[string]string{"nginx": "mirror.example.com/nginx:1.15"},
+		},
+		"registry": {
+			Images: map[string]string{"registry.hub.docker.com/library/registry:2.6.1": "registry:2"},
+		},
+	}
+	if !reflect.DeepEqual(k.AddonImages, expected) {
+		t.Errorf("expected %v, got %v", expected, k.AddonImages)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "sort"
+
+// AddCachedImages adds images to the cache of the profile, ignoring the ones it already has
+func (c *Config) AddCachedImages(images []string) {
+	set := map[string]bool{}
+	for _, i := range c.CachedImages {
+		set[i] = true
+	}
+	for _, i := range images {
+		if !set[i] {
+			set[i] = true
+			c.CachedImages = append(c.CachedImages, i)
+		}
+	}
+	sort.Strings(c.CachedImages)
+}
+
+// RemoveCachedImages removes images from the cache of the profile, returning whether any was removed
+func (c *Config) RemoveCachedImages(images []string) bool {
+	remove := map[string]bool{}
+	for _, i := range images {
+		remove[i] = true
+	}
+	var kept []string
+	for _, i := range c.CachedImages {
+		if !remove[i] {
+			kept = append(kept, i)
+		}
+	}
+	removed := len(kept) != len(c.CachedImages)
+	c.CachedImages = kept
+	return removed
+}
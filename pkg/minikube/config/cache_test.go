@@ -0,0 +1,43 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCachedImages(t *testing.T) {
+	c := &Config{}
+	c.AddCachedImages([]string{"redis:3", "busybox"})
+	c.AddCachedImages([]string{"busybox", "alpine"})
+	want := []string{"alpine", "busybox", "redis:3"}
+	if !reflect.DeepEqual(c.CachedImages, want) {
+		t.Errorf("CachedImages = %v, want %v", c.CachedImages, want)
+	}
+
+	if !c.RemoveCachedImages([]string{"busybox", "ubuntu"}) {
+		t.Errorf("RemoveCachedImages() = false, want true")
+	}
+	if c.RemoveCachedImages([]string{"ubuntu"}) {
+		t.Errorf("RemoveCachedImages() of a missing image = true, want false")
+	}
+	want = []string{"alpine", "redis:3"}
+	if !reflect.DeepEqual(c.CachedImages, want) {
+		t.Errorf("CachedImages = %v, want %v", c.CachedImages, want)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "fmt"
+
+// MachineName returns the name of the machine backing the node in the given profile
+func (n Node) MachineName(profile string) string {
+	return fmt.Sprintf("%s-%s", profile, n.Name)
+}
+
+// FindNode returns the index of the named worker node, or -1 if it does not exist
+func (c *Config) FindNode(name string) int {
+	for i, n := range c.Nodes {
+		if n.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// NextNodeName returns the first unused worker node name, starting from m02
+func (c *Config) NextNodeName() string {
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("m%02d", i)
+		if c.FindNode(name) == -1 {
+			return name
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "testing"
+
+func TestNextNodeName(t *testing.T) {
+	var tests = []struct {
+		description string
+		nodes       []Node
+		expected    string
+	}{
+		{
+			description: "no nodes",
+			expected:    "m02",
+		},
+		{
+			description: "sequential nodes",
+			nodes:       []Node{{Name: "m02"}, {Name: "m03"}},
+			expected:    "m04",
+		},
+		{
+			description: "reuse deleted node name",
+			nodes:       []Node{{Name: "m03"}},
+			expected:    "m02",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			c := &Config{Nodes: test.nodes}
+			if got := c.NextNodeName(); got != test.expected {
+				t.Errorf("NextNodeName() = %q, expected %q", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestFindNode(t *testing.T) {
+	c := &Config{Nodes: []Node{{Name: "m02"}, {Name: "m03"}}}
+	if i := c.FindNode("m03"); i != 1 {
+		t.Errorf("FindNode(m03) = %d, expected 1", i)
+	}
+	if i := c.FindNode("m04"); i != -1 {
+		t.Errorf("FindNode(m04) = %d, expected -1", i)
+	}
+}
+
+func TestNodeMachineName(t *testing.T) {
+	n := Node{Name: "m02"}
+	if got := n.MachineName("minikube"); got != "minikube-m02" {
+		t.Errorf("MachineName() = %q, expected %q", got, "minikube-m02")
+	}
+}
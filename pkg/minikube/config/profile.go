@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+// Profile represents a minikube profile
+type Profile struct {
+	Name   string
+	Config *Config
+}
+
+// ListProfiles returns the profiles found in $MINIKUBE_HOME/profiles, split into the
+// ones whose config could be loaded and the ones whose config is missing or corrupt
+func ListProfiles() (valid []*Profile, invalid []*Profile, err error) {
+	files, err := ioutil.ReadDir(filepath.Join(constants.GetMinipath(), "profiles"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+		p := &Profile{Name: f.Name()}
+		cc, err := DefaultLoader.LoadConfigFromFile(p.Name)
+		if err != nil {
+			glog.Infof("invalid profile %s: %v", p.Name, err)
+			invalid = append(invalid, p)
+			continue
+		}
+		p.Config = cc
+		valid = append(valid, p)
+	}
+	return valid, invalid, nil
+}
+
+// SaveProfile saves profile cluster configuration in $MINIKUBE_HOME/profiles/<profilename>/config.json
+func SaveProfile(profile string, cfg *Config) error {
+	data, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return err
+	}
+	glog.Infof("Saving config:\n%s", data)
+	path := constants.GetProfileFile(profile)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	// If no config file exists, don't worry about swapping paths
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	tf, err := ioutil.TempFile(filepath.Dir(path), "config.json.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+
+	if err = ioutil.WriteFile(tf.Name(), data, 0600); err != nil {
+		return err
+	}
+
+	if err = tf.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tf.Name(), path); err != nil {
+		return err
+	}
+	return nil
+}
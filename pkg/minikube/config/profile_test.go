@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func TestListProfiles(t *testing.T) {
+	miniDir, err := filepath.Abs("./testdata/.minikube")
+	if err != nil {
+		t.Fatalf("error getting dir path for ./testdata/.minikube : %v", err)
+	}
+	os.Setenv(constants.MinikubeHome, miniDir)
+	defer os.Unsetenv(constants.MinikubeHome)
+
+	valid, invalid, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("error listing profiles: %v", err)
+	}
+
+	if len(valid) != 1 || valid[0].Name != "p1" {
+		t.Fatalf("expected only p1 to be valid, got %+v", valid)
+	}
+	if valid[0].Config.MachineConfig.VMDriver != "virtualbox" {
+		t.Errorf("expected p1 VMDriver to be virtualbox, got %q", valid[0].Config.MachineConfig.VMDriver)
+	}
+	if valid[0].Config.KubernetesConfig.KubernetesVersion != "v1.14.1" {
+		t.Errorf("expected p1 KubernetesVersion to be v1.14.1, got %q", valid[0].Config.KubernetesConfig.KubernetesVersion)
+	}
+
+	var names []string
+	for _, p := range invalid {
+		names = append(names, p.Name)
+	}
+	if len(names) != 2 || names[0] != "p2" || names[1] != "p_empty" {
+		t.Errorf("expected p2 and p_empty to be invalid, got %v", names)
+	}
+}
+
+func TestListProfilesNoProfilesDir(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "minikube")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	os.Setenv(constants.MinikubeHome, tempDir)
+	defer os.Unsetenv(constants.MinikubeHome)
+
+	valid, invalid, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("error listing profiles: %v", err)
+	}
+	if len(valid) != 0 || len(invalid) != 0 {
+		t.Errorf("expected no profiles, got %v and %v", valid, invalid)
+	}
+}
+
+func TestSaveProfile(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "minikube")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	os.Setenv(constants.MinikubeHome, tempDir)
+	defer os.Unsetenv(constants.MinikubeHome)
+
+	cc := &Config{KubernetesConfig: KubernetesConfig{KubernetesVersion: "v1.14.1"}}
+	// Save twice, to exercise both the create and the replace path.
+	for i := 0; i < 2; i++ {
+		if err := SaveProfile("p1", cc); err != nil {
+			t.Fatalf("error saving profile: %v", err)
+		}
+	}
+	got, err := DefaultLoader.LoadConfigFromFile("p1")
+	if err != nil {
+		t.Fatalf("error loading profile: %v", err)
+	}
+	if got.KubernetesConfig.KubernetesVersion != "v1.14.1" {
+		t.Errorf("expected KubernetesVersion to be v1.14.1, got %q", got.KubernetesConfig.KubernetesVersion)
+	}
+}
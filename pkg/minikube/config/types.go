@@ -26,10 +26,19 @@ import (
 type Config struct {
 	MachineConfig    MachineConfig
 	KubernetesConfig KubernetesConfig
+	Nodes            []Node
+	CachedImages     []string // Images cached for this profile only, in addition to the global cache
+}
+
+// Node contains the configuration of a worker node which has joined the cluster
+type Node struct {
+	Name string // Unique within the profile, e.g. m02
+	IP   string
 }
 
 // MachineConfig contains the parameters used to start a cluster.
 type MachineConfig struct {
+	Name                string `json:"-"` // Overrides the machine name, which defaults to the profile name
 	MinikubeISO         string
 	Memory              int
 	CPUs                int
@@ -56,6 +65,14 @@ type MachineConfig struct {
 	NoVTXCheck          bool   // Only used by virtualbox
 }
 
+// MachineName returns the name of the machine to create
+func (c MachineConfig) MachineName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return GetMachineName()
+}
+
 // KubernetesConfig contains the parameters used to configure the VM Kubernetes.
 type KubernetesConfig struct {
 	KubernetesVersion string
@@ -76,4 +93,16 @@ type KubernetesConfig struct {
 
 	ShouldLoadCachedImages bool
 	EnableDefaultCNI       bool
+
+	AddonValues map[string]map[string]string // Values set with "minikube addons configure", per addon
+	AddonImages map[string]AddonImages       // Image overrides set with "minikube addons configure", per addon
+
+	ConfigPatch     string `json:",omitempty"` // YAML patch merged on top of the generated kubeadm configuration
+	ConfigPatchType string `json:",omitempty"` // How ConfigPatch is merged: "strategic" (default) or "merge"
+}
+
+// AddonImages overrides the images of the manifests of an addon
+type AddonImages struct {
+	Registry string            `json:",omitempty"` // Registry replaces the registry of every image of the addon
+	Images   map[string]string `json:",omitempty"` // Images replaces images by full references, before Registry applies
 }
@@ -61,6 +61,8 @@ var styles = map[string]style{
 	"reconfiguring": {Prefix: "📯  "},
 	"stopping":      {Prefix: "✋  "},
 	"stopped":       {Prefix: "🛑  "},
+	"paused":        {Prefix: "⏸️  "},
+	"unpaused":      {Prefix: "⏯️  "},
 	"warning":       {Prefix: "⚠️  ", LowPrefix: lowWarning},
 	"waiting":       {Prefix: "⌛  "},
 	"waiting-pods":  {Prefix: "⌛  ", OmitNewline: true},
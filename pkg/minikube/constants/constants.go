@@ -107,6 +107,16 @@ func TunnelRegistryPath() string {
 	return filepath.Join(GetMinipath(), "tunnels.json")
 }
 
+// LoadBalancerIPsPath returns the path to the file of LoadBalancer IPs allocated by tunnels
+func LoadBalancerIPsPath() string {
+	return filepath.Join(GetMinipath(), "loadbalancer_ips.json")
+}
+
+// ThirdPartyAddonsPath returns the directory third-party addons are loaded from, one directory per addon
+func ThirdPartyAddonsPath() string {
+	return filepath.Join(GetMinipath(), "addons.d")
+}
+
 // MakeMiniPath is a utility to calculate a relative path to our directory.
 func MakeMiniPath(fileName ...string) string {
 	args := []string{GetMinipath()}
@@ -201,10 +211,22 @@ const (
 	KubeletSystemdConfFile = "/etc/systemd/system/kubelet.service.d/10-kubeadm.conf"
 	// KubeadmConfigFile is the path to the kubeadm configuration
 	KubeadmConfigFile = "/var/lib/kubeadm.yaml"
+	// KubeletConfigFile is the path to the kubelet configuration, which is only used when a config patch changes it
+	KubeletConfigFile = "/var/lib/kubelet/config.yaml"
+	// KubeletKubeconfigFile is the path to the kubeconfig kubeadm writes for the kubelet
+	KubeletKubeconfigFile = "/etc/kubernetes/kubelet.conf"
+	// KubeadmCACertFile is the path to the cluster CA which kubeadm join installs on worker nodes
+	KubeadmCACertFile = "/etc/kubernetes/pki/ca.crt"
 	// DefaultCNIConfigPath is the path to the CNI configuration
 	DefaultCNIConfigPath = "/etc/cni/net.d/k8s.conf"
 	// DefaultRktNetConfigPath is the path to the rkt net configuration
 	DefaultRktNetConfigPath = "/etc/rkt/net.d/k8s.conf"
+	// K3sServiceFile is the path to the systemd service of the k3s server
+	K3sServiceFile = "/lib/systemd/system/k3s.service"
+	// K3sAgentServiceFile is the path to the systemd service of the k3s agent, on worker nodes
+	K3sAgentServiceFile = "/lib/systemd/system/k3s-agent.service"
+	// K3sDataDir is where k3s stores the state of the cluster
+	K3sDataDir = "/var/lib/minikube/k3s"
 )
 
 const (
@@ -245,11 +267,52 @@ const DriverNone = "none"
 // FileScheme is the file scheme
 const FileScheme = "file"
 
+// K3sReleases maps the Kubernetes versions supported by the k3s bootstrapper to the k3s release shipping them
+var K3sReleases = map[string]string{
+	"v1.14.1": "v0.5.0",
+}
+
+// GetK3sReleaseURL gets the location of the k3s binary shipping a Kubernetes version
+func GetK3sReleaseURL(kubernetesVersion, archName string) (string, error) {
+	release, ok := K3sReleases[kubernetesVersion]
+	if !ok {
+		return "", fmt.Errorf("no k3s release ships Kubernetes %s", kubernetesVersion)
+	}
+	binary := "k3s"
+	switch archName {
+	case "amd64":
+	case "arm":
+		binary = "k3s-armhf"
+	default:
+		binary = "k3s-" + archName
+	}
+	return fmt.Sprintf("https://github.com/rancher/k3s/releases/download/%s/%s", release, binary), nil
+}
+
+// GetK3sReleaseURLSHA256 gets the location of the checksums of the k3s binaries shipping a Kubernetes version
+func GetK3sReleaseURLSHA256(kubernetesVersion, archName string) (string, error) {
+	if _, err := GetK3sReleaseURL(kubernetesVersion, archName); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://github.com/rancher/k3s/releases/download/%s/sha256sum-%s.txt", K3sReleases[kubernetesVersion], archName), nil
+}
+
+// GetK3sCachedBinaries gets the binaries to cache for k3s
+func GetK3sCachedBinaries() []string {
+	return []string{"k3s"}
+}
+
 // GetKubeadmCachedBinaries gets the binaries to cache for kubeadm
 func GetKubeadmCachedBinaries() []string {
 	return []string{"kubelet", "kubeadm"}
 }
 
+// GetGuestBinariesDir returns the directory of the node for the binaries of a Kubernetes version which are
+// installed apart from the ones in the PATH, so that they never replace the ones of the host with --vm-driver=none
+func GetGuestBinariesDir(kubernetesVersion string) string {
+	return fmt.Sprintf("/var/lib/minikube/binaries/%s", kubernetesVersion)
+}
+
 // GetKubeadmCachedImages gets the images to cache for kubeadm for a version
 func GetKubeadmCachedImages(imageRepository string, kubernetesVersionStr string) (string, []string) {
 	minikubeRepository := imageRepository
@@ -18,11 +18,16 @@ package cruntime
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 )
 
+// containerdRuncRoot is the state directory of the runc instance containerd uses for Kubernetes
+const containerdRuncRoot = "/run/containerd/runc/k8s.io"
+
 // Containerd contains containerd runtime state
 type Containerd struct {
 	Socket string
@@ -96,7 +101,33 @@ func (r *Containerd) Disable() error {
 // LoadImage loads an image into this runtime
 func (r *Containerd) LoadImage(path string) error {
 	glog.Infof("Loading image: %s", path)
-	return r.Runner.Run(fmt.Sprintf("sudo ctr images import %s", path))
+	// Images must be in the k8s.io namespace to be visible to the CRI plugin
+	return r.Runner.Run(fmt.Sprintf("sudo ctr -n=k8s.io images import %s", path))
+}
+
+// ListImages returns the names of the images present in this runtime
+func (r *Containerd) ListImages() ([]string, error) {
+	return listCRIImages(r.Runner)
+}
+
+// RemoveImage removes an image from this runtime
+func (r *Containerd) RemoveImage(name string) error {
+	return removeCRIImage(r.Runner, name)
+}
+
+// BuildImage builds an image using buildkit, which must use the containerd worker in the k8s.io namespace
+func (r *Containerd) BuildImage(src string, file string, tag string) error {
+	if _, err := r.Runner.CombinedOutput("which buildctl"); err != nil {
+		return fmt.Errorf("buildctl was not found: buildkit is required to build images with containerd")
+	}
+	if err := r.Runner.Run("sudo systemctl start buildkit"); err != nil {
+		return errors.Wrap(err, "starting buildkit")
+	}
+	tag = qualifiedImageName(tag)
+	glog.Infof("Building image %s from %s", tag, src)
+	dir := path.Dir(path.Join(src, file))
+	return r.Runner.Run(fmt.Sprintf("sudo buildctl build --frontend=dockerfile.v0 --local context=%s --local dockerfile=%s --opt filename=%s --output type=image,name=%s,unpack=true",
+		src, dir, path.Base(file), tag))
 }
 
 // KubeletOptions returns kubelet options for a containerd
@@ -124,6 +155,21 @@ func (r *Containerd) StopContainers(ids []string) error {
 	return stopCRIContainers(r.Runner, ids)
 }
 
+// ListKubernetesContainers returns the IDs of containers managed by Kubernetes in a given state
+func (r *Containerd) ListKubernetesContainers(state ContainerState) ([]string, error) {
+	return listRuncContainers(r.Runner, containerdRuncRoot, state)
+}
+
+// PauseContainers pauses running containers based on ID
+func (r *Containerd) PauseContainers(ids []string) error {
+	return pauseRuncContainers(r.Runner, containerdRuncRoot, ids)
+}
+
+// UnpauseContainers resumes paused containers based on ID
+func (r *Containerd) UnpauseContainers(ids []string) error {
+	return unpauseRuncContainers(r.Runner, containerdRuncRoot, ids)
+}
+
 // ContainerLogCmd returns the command to retrieve the log for a container based on ID
 func (r *Containerd) ContainerLogCmd(id string, len int, follow bool) string {
 	return criContainerLogCmd(id, len, follow)
@@ -18,6 +18,7 @@ package cruntime
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"path"
@@ -66,6 +67,92 @@ func stopCRIContainers(cr CommandRunner, ids []string) error {
 	return cr.Run(fmt.Sprintf("sudo crictl stop %s", strings.Join(ids, " ")))
 }
 
+// listRuncContainers returns the IDs of containers in a given state, as seen by runc.
+// CRI has no notion of paused containers, so runc is queried directly.
+func listRuncContainers(cr CommandRunner, root string, state ContainerState) ([]string, error) {
+	content, err := cr.CombinedOutput(fmt.Sprintf("sudo runc --root %s list -f json", root))
+	if err != nil {
+		return nil, err
+	}
+	var cs []struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	// runc prints "null" when there are no containers
+	if err := json.Unmarshal([]byte(content), &cs); err != nil {
+		return nil, fmt.Errorf("parsing runc list: %v", err)
+	}
+	var ids []string
+	for _, c := range cs {
+		if c.Status == string(state) {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids, nil
+}
+
+// pauseRuncContainers pauses containers using runc
+func pauseRuncContainers(cr CommandRunner, root string, ids []string) error {
+	glog.Infof("Pausing containers: %s", ids)
+	for _, id := range ids {
+		if err := cr.Run(fmt.Sprintf("sudo runc --root %s pause %s", root, id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unpauseRuncContainers resumes containers using runc
+func unpauseRuncContainers(cr CommandRunner, root string, ids []string) error {
+	glog.Infof("Unpausing containers: %s", ids)
+	for _, id := range ids {
+		if err := cr.Run(fmt.Sprintf("sudo runc --root %s resume %s", root, id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listCRIImages returns the tagged names of the images known to crictl
+func listCRIImages(cr CommandRunner) ([]string, error) {
+	content, err := cr.CombinedOutput("sudo crictl images -o json")
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Images []struct {
+			RepoTags []string `json:"repoTags"`
+		} `json:"images"`
+	}
+	if err := json.Unmarshal([]byte(content), &list); err != nil {
+		return nil, fmt.Errorf("parsing crictl images: %v", err)
+	}
+	var images []string
+	for _, i := range list.Images {
+		images = append(images, i.RepoTags...)
+	}
+	return images, nil
+}
+
+// removeCRIImage removes an image using crictl
+func removeCRIImage(cr CommandRunner, name string) error {
+	glog.Infof("Removing image: %s", name)
+	return cr.Run(fmt.Sprintf("sudo crictl rmi %s", name))
+}
+
+// qualifiedImageName returns an image name including its registry, as kubelet resolves it through CRI
+func qualifiedImageName(name string) string {
+	i := strings.Index(name, "/")
+	if i == -1 {
+		return "docker.io/library/" + name
+	}
+	// A registry is recognized the same way as by docker: it contains a dot or port, or is localhost
+	if domain := name[:i]; !strings.ContainsAny(domain, ".:") && domain != "localhost" {
+		return "docker.io/" + name
+	}
+	return name
+}
+
 // populateCRIConfig sets up /etc/crictl.yaml
 func populateCRIConfig(cr CommandRunner, socket string) error {
 	cPath := "/etc/crictl.yaml"
@@ -18,11 +18,15 @@ package cruntime
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/golang/glog"
 )
 
+// crioRuncRoot is the state directory of the runc instance used by CRIO
+const crioRuncRoot = "/run/runc"
+
 // CRIO contains CRIO runtime state
 type CRIO struct {
 	Socket string
@@ -96,6 +100,24 @@ func (r *CRIO) LoadImage(path string) error {
 	return r.Runner.Run(fmt.Sprintf("sudo podman load -i %s", path))
 }
 
+// ListImages returns the names of the images present in this runtime
+func (r *CRIO) ListImages() ([]string, error) {
+	return listCRIImages(r.Runner)
+}
+
+// RemoveImage removes an image from this runtime
+func (r *CRIO) RemoveImage(name string) error {
+	return removeCRIImage(r.Runner, name)
+}
+
+// BuildImage builds an image using podman, which shares its image storage with CRI-O
+func (r *CRIO) BuildImage(src string, file string, tag string) error {
+	// podman qualifies short names with localhost/, where kubelet would not find them
+	tag = qualifiedImageName(tag)
+	glog.Infof("Building image %s from %s", tag, src)
+	return r.Runner.Run(fmt.Sprintf("sudo podman build -t %s -f %s %s", tag, path.Join(src, file), src))
+}
+
 // KubeletOptions returns kubelet options for a runtime.
 func (r *CRIO) KubeletOptions() map[string]string {
 	return map[string]string{
@@ -121,6 +143,21 @@ func (r *CRIO) StopContainers(ids []string) error {
 	return stopCRIContainers(r.Runner, ids)
 }
 
+// ListKubernetesContainers returns the IDs of containers managed by Kubernetes in a given state
+func (r *CRIO) ListKubernetesContainers(state ContainerState) ([]string, error) {
+	return listRuncContainers(r.Runner, crioRuncRoot, state)
+}
+
+// PauseContainers pauses running containers based on ID
+func (r *CRIO) PauseContainers(ids []string) error {
+	return pauseRuncContainers(r.Runner, crioRuncRoot, ids)
+}
+
+// UnpauseContainers resumes paused containers based on ID
+func (r *CRIO) UnpauseContainers(ids []string) error {
+	return unpauseRuncContainers(r.Runner, crioRuncRoot, ids)
+}
+
 // ContainerLogCmd returns the command to retrieve the log for a container based on ID
 func (r *CRIO) ContainerLogCmd(id string, len int, follow bool) string {
 	return criContainerLogCmd(id, len, follow)
@@ -54,6 +54,12 @@ type Manager interface {
 
 	// Load an image idempotently into the runtime on a host
 	LoadImage(string) error
+	// ListImages returns the names of the images present in the runtime on a host
+	ListImages() ([]string, error)
+	// RemoveImage removes an image from the runtime on a host
+	RemoveImage(string) error
+	// BuildImage builds an image from a context directory and Dockerfile on a host, and tags it
+	BuildImage(src string, file string, tag string) error
 
 	// ListContainers returns a list of managed by this container runtime
 	ListContainers(string) ([]string, error)
@@ -61,10 +67,26 @@ type Manager interface {
 	KillContainers([]string) error
 	// StopContainers stops containers based on ID
 	StopContainers([]string) error
+	// ListKubernetesContainers returns the IDs of containers managed by Kubernetes in a given state
+	ListKubernetesContainers(ContainerState) ([]string, error)
+	// PauseContainers pauses running containers based on ID
+	PauseContainers([]string) error
+	// UnpauseContainers resumes paused containers based on ID
+	UnpauseContainers([]string) error
 	// ContainerLogCmd returns the command to retrieve the log for a container based on ID
 	ContainerLogCmd(string, int, bool) string
 }
 
+// ContainerState is the state of a container, as reported by the runtime
+type ContainerState string
+
+const (
+	// ContainerRunning is the state of a container whose processes are running
+	ContainerRunning ContainerState = "running"
+	// ContainerPaused is the state of a container whose processes are frozen
+	ContainerPaused ContainerState = "paused"
+)
+
 // Config is runtime configuration
 type Config struct {
 	// Type of runtime to create ("docker, "crio", etc)
@@ -17,6 +17,7 @@ limitations under the License.
 package cruntime
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -101,6 +102,8 @@ type FakeRunner struct {
 	cmds       []string
 	services   map[string]serviceState
 	containers map[string]string
+	paused     map[string]bool
+	images     map[string]bool
 	t          *testing.T
 }
 
@@ -111,6 +114,8 @@ func NewFakeRunner(t *testing.T) *FakeRunner {
 		cmds:       []string{},
 		t:          t,
 		containers: map[string]string{},
+		paused:     map[string]bool{},
+		images:     map[string]bool{},
 	}
 }
 
@@ -137,6 +142,12 @@ func (f *FakeRunner) CombinedOutput(cmd string) (string, error) {
 		return f.crio(args, root)
 	case "containerd":
 		return f.containerd(args, root)
+	case "runc":
+		return f.runc(args, root)
+	case "podman":
+		return f.podman(args, root)
+	case "buildctl":
+		return f.buildctl(args, root)
 	default:
 		return "", nil
 	}
@@ -166,6 +177,44 @@ func (f *FakeRunner) docker(args []string, root bool) (string, error) {
 			f.t.Logf("fake docker: Found containers: %v", ids)
 			return strings.Join(ids, "\n"), nil
 		}
+		// ps --filter="name=k8s_" --filter="status=paused" --format="{{.ID}}"
+		if strings.HasPrefix(args[1], "--filter") && strings.HasPrefix(args[2], "--filter") {
+			fname := strings.Split(strings.Split(args[1], `"`)[1], "=")[1]
+			status := strings.Split(strings.Split(args[2], `"`)[1], "=")[1]
+			ids := []string{}
+			for id, cname := range f.containers {
+				if strings.Contains(cname, fname) && f.containerState(id) == status {
+					ids = append(ids, id)
+				}
+			}
+			f.t.Logf("fake docker: Found %s containers: %v", status, ids)
+			return strings.Join(ids, "\n"), nil
+		}
+	case "images":
+		var images []string
+		for name := range f.images {
+			images = append(images, name)
+		}
+		// Untagged images are listed too
+		images = append(images, "<none>:<none>")
+		return strings.Join(images, "\n"), nil
+	case "rmi":
+		name := args[1]
+		if !f.images[name] {
+			return "", fmt.Errorf("no such image: %s", name)
+		}
+		delete(f.images, name)
+	case "build":
+		// build -t tag -f file context
+		f.images[args[2]] = true
+	case "pause", "unpause":
+		for _, id := range args[1:] {
+			f.t.Logf("fake docker: %s id %q", cmd, id)
+			if f.containers[id] == "" {
+				return "", fmt.Errorf("no such container")
+			}
+			f.paused[id] = cmd == "pause"
+		}
 	case "stop":
 		for _, id := range args[1:] {
 			f.t.Logf("fake docker: Stopping id %q", id)
@@ -193,6 +242,83 @@ func (f *FakeRunner) docker(args []string, root bool) (string, error) {
 	return "", nil
 }
 
+// containerState returns the runc state of a fake container
+func (f *FakeRunner) containerState(id string) string {
+	if f.paused[id] {
+		return "paused"
+	}
+	return "running"
+}
+
+// runc is a fake implementation of runc
+func (f *FakeRunner) runc(args []string, root bool) (string, error) {
+	if !root {
+		return "", fmt.Errorf("not root")
+	}
+	// Skip "--root <dir>" arguments
+	if args[0] == "--root" {
+		args = args[2:]
+	}
+	switch cmd := args[0]; cmd {
+	case "list":
+		type container struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		}
+		var cs []container
+		for id := range f.containers {
+			cs = append(cs, container{ID: id, Status: f.containerState(id)})
+		}
+		out, err := json.Marshal(cs)
+		return string(out), err
+	case "pause", "resume":
+		id := args[1]
+		f.t.Logf("fake runc: %s id %q", cmd, id)
+		if f.containers[id] == "" {
+			return "", fmt.Errorf("no such container")
+		}
+		if (cmd == "pause") == f.paused[id] {
+			return "", fmt.Errorf("container %s is already %s", id, f.containerState(id))
+		}
+		f.paused[id] = cmd == "pause"
+	}
+	return "", nil
+}
+
+// podman is a fake implementation of podman
+func (f *FakeRunner) podman(args []string, root bool) (string, error) {
+	if !root {
+		return "", fmt.Errorf("not root")
+	}
+	switch cmd := args[0]; cmd {
+	case "build":
+		// build -t tag -f file context
+		f.images[args[2]] = true
+	}
+	return "", nil
+}
+
+// buildctl is a fake implementation of buildctl
+func (f *FakeRunner) buildctl(args []string, root bool) (string, error) {
+	if !root {
+		return "", fmt.Errorf("not root")
+	}
+	if f.services["buildkit"] != Running {
+		return "", fmt.Errorf("buildkitd is not running")
+	}
+	for _, arg := range args {
+		// --output type=image,name=tag,unpack=true
+		if strings.HasPrefix(arg, "type=image,") {
+			for _, opt := range strings.Split(arg, ",") {
+				if strings.HasPrefix(opt, "name=") {
+					f.images[strings.TrimPrefix(opt, "name=")] = true
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
 // crio is a fake implementation of crio
 func (f *FakeRunner) crio(args []string, root bool) (string, error) {
 	switch cmd := args[0]; cmd {
@@ -236,6 +362,24 @@ func (f *FakeRunner) crictl(args []string, root bool) (string, error) {
 			return strings.Join(ids, "\n"), nil
 
 		}
+	case "images":
+		type image struct {
+			RepoTags []string `json:"repoTags"`
+		}
+		var list struct {
+			Images []image `json:"images"`
+		}
+		for name := range f.images {
+			list.Images = append(list.Images, image{RepoTags: []string{name}})
+		}
+		out, err := json.Marshal(list)
+		return string(out), err
+	case "rmi":
+		name := args[1]
+		if !f.images[name] {
+			return "", fmt.Errorf("no such image: %s", name)
+		}
+		delete(f.images, name)
 	case "stop":
 		for _, id := range args[1:] {
 			f.t.Logf("fake crictl: Stopping id %q", id)
@@ -493,3 +637,165 @@ func TestContainerFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestPauseFunctions(t *testing.T) {
+	var tests = []struct {
+		runtime string
+	}{
+		{"docker"},
+		{"crio"},
+		{"containerd"},
+	}
+
+	sortSlices := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	for _, tc := range tests {
+		t.Run(tc.runtime, func(t *testing.T) {
+			runner := NewFakeRunner(t)
+			prefix := ""
+			if tc.runtime == "docker" {
+				prefix = "k8s_"
+			}
+			runner.containers = map[string]string{
+				"abc0": prefix + "apiserver",
+				"fgh1": prefix + "coredns",
+			}
+			cr, err := New(Config{Type: tc.runtime, Runner: runner})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+
+			running, err := cr.ListKubernetesContainers(ContainerRunning)
+			if err != nil {
+				t.Fatalf("ListKubernetesContainers: %v", err)
+			}
+			want := []string{"abc0", "fgh1"}
+			if diff := cmp.Diff(running, want, sortSlices); diff != "" {
+				t.Errorf("ListKubernetesContainers(running) unexpected results, diff (-got + want): %s", diff)
+			}
+
+			if err := cr.PauseContainers(running); err != nil {
+				t.Fatalf("PauseContainers: %v", err)
+			}
+			paused, err := cr.ListKubernetesContainers(ContainerPaused)
+			if err != nil {
+				t.Fatalf("ListKubernetesContainers: %v", err)
+			}
+			if diff := cmp.Diff(paused, want, sortSlices); diff != "" {
+				t.Errorf("ListKubernetesContainers(paused) unexpected results, diff (-got + want): %s", diff)
+			}
+			running, err = cr.ListKubernetesContainers(ContainerRunning)
+			if err != nil {
+				t.Fatalf("ListKubernetesContainers: %v", err)
+			}
+			if len(running) > 0 {
+				t.Errorf("ListKubernetesContainers(running) = %v, want 0 items", running)
+			}
+
+			if err := cr.UnpauseContainers(paused); err != nil {
+				t.Fatalf("UnpauseContainers: %v", err)
+			}
+			paused, err = cr.ListKubernetesContainers(ContainerPaused)
+			if err != nil {
+				t.Fatalf("ListKubernetesContainers: %v", err)
+			}
+			if len(paused) > 0 {
+				t.Errorf("ListKubernetesContainers(paused) = %v, want 0 items", paused)
+			}
+		})
+	}
+}
+
+func TestImageFunctions(t *testing.T) {
+	var tests = []struct {
+		runtime string
+	}{
+		{"docker"},
+		{"crio"},
+		{"containerd"},
+	}
+
+	sortSlices := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	for _, tc := range tests {
+		t.Run(tc.runtime, func(t *testing.T) {
+			runner := NewFakeRunner(t)
+			runner.images = map[string]bool{
+				"k8s.gcr.io/pause:3.1": true,
+				"busybox:latest":       true,
+			}
+			cr, err := New(Config{Type: tc.runtime, Runner: runner})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+
+			got, err := cr.ListImages()
+			if err != nil {
+				t.Fatalf("ListImages: %v", err)
+			}
+			want := []string{"busybox:latest", "k8s.gcr.io/pause:3.1"}
+			if diff := cmp.Diff(got, want, sortSlices); diff != "" {
+				t.Errorf("ListImages() unexpected results, diff (-got + want): %s", diff)
+			}
+
+			if err := cr.RemoveImage("busybox:latest"); err != nil {
+				t.Fatalf("RemoveImage: %v", err)
+			}
+			got, err = cr.ListImages()
+			if err != nil {
+				t.Fatalf("ListImages: %v", err)
+			}
+			want = []string{"k8s.gcr.io/pause:3.1"}
+			if diff := cmp.Diff(got, want, sortSlices); diff != "" {
+				t.Errorf("ListImages() after RemoveImage unexpected results, diff (-got + want): %s", diff)
+			}
+			if err := cr.RemoveImage("busybox:latest"); err == nil {
+				t.Errorf("RemoveImage of a missing image succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestBuildImage(t *testing.T) {
+	var tests = []struct {
+		runtime string
+		want    string
+	}{
+		{"docker", "my-app:dev"},
+		{"crio", "docker.io/library/my-app:dev"},
+		{"containerd", "docker.io/library/my-app:dev"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.runtime, func(t *testing.T) {
+			runner := NewFakeRunner(t)
+			runner.services["buildkit"] = Exited
+			cr, err := New(Config{Type: tc.runtime, Runner: runner})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+			if err := cr.BuildImage("/tmp/build", "Dockerfile", "my-app:dev"); err != nil {
+				t.Fatalf("BuildImage: %v", err)
+			}
+			if !runner.images[tc.want] {
+				t.Errorf("BuildImage did not produce %s, images: %v", tc.want, runner.images)
+			}
+		})
+	}
+}
+
+func TestQualifiedImageName(t *testing.T) {
+	var tests = []struct {
+		name string
+		want string
+	}{
+		{"busybox", "docker.io/library/busybox"},
+		{"busybox:1.30", "docker.io/library/busybox:1.30"},
+		{"user/app:v1", "docker.io/user/app:v1"},
+		{"k8s.gcr.io/pause:3.1", "k8s.gcr.io/pause:3.1"},
+		{"localhost/app", "localhost/app"},
+		{"registry:5000/app", "registry:5000/app"},
+	}
+	for _, tc := range tests {
+		if got := qualifiedImageName(tc.name); got != tc.want {
+			t.Errorf("qualifiedImageName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package cruntime
 import (
 	"fmt"
 	"os/exec"
+	"path"
 	"strings"
 
 	"github.com/golang/glog"
@@ -87,6 +88,34 @@ func (r *Docker) LoadImage(path string) error {
 	return r.Runner.Run(fmt.Sprintf("docker load -i %s", path))
 }
 
+// ListImages returns the names of the images present in this runtime
+func (r *Docker) ListImages() ([]string, error) {
+	content, err := r.Runner.CombinedOutput(`docker images --format="{{.Repository}}:{{.Tag}}"`)
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	for _, line := range strings.Split(content, "\n") {
+		// Untagged images can only be referred to by ID, so they are of no use to pods
+		if line != "" && !strings.Contains(line, "<none>") {
+			images = append(images, line)
+		}
+	}
+	return images, nil
+}
+
+// RemoveImage removes an image from this runtime
+func (r *Docker) RemoveImage(name string) error {
+	glog.Infof("Removing image: %s", name)
+	return r.Runner.Run(fmt.Sprintf("docker rmi %s", name))
+}
+
+// BuildImage builds an image using docker build
+func (r *Docker) BuildImage(src string, file string, tag string) error {
+	glog.Infof("Building image %s from %s", tag, src)
+	return r.Runner.Run(fmt.Sprintf("docker build -t %s -f %s %s", tag, path.Join(src, file), src))
+}
+
 // KubeletOptions returns kubelet options for a runtime.
 func (r *Docker) KubeletOptions() map[string]string {
 	return map[string]string{
@@ -127,6 +156,39 @@ func (r *Docker) StopContainers(ids []string) error {
 	return r.Runner.Run(fmt.Sprintf("docker stop %s", strings.Join(ids, " ")))
 }
 
+// ListKubernetesContainers returns the IDs of containers managed by Kubernetes in a given state
+func (r *Docker) ListKubernetesContainers(state ContainerState) ([]string, error) {
+	content, err := r.Runner.CombinedOutput(fmt.Sprintf(`docker ps --filter="name=k8s_" --filter="status=%s" --format="{{.ID}}"`, state))
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, line := range strings.Split(content, "\n") {
+		if line != "" {
+			ids = append(ids, line)
+		}
+	}
+	return ids, nil
+}
+
+// PauseContainers pauses running containers based on ID
+func (r *Docker) PauseContainers(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	glog.Infof("Pausing containers: %s", ids)
+	return r.Runner.Run(fmt.Sprintf("docker pause %s", strings.Join(ids, " ")))
+}
+
+// UnpauseContainers resumes paused containers based on ID
+func (r *Docker) UnpauseContainers(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	glog.Infof("Unpausing containers: %s", ids)
+	return r.Runner.Run(fmt.Sprintf("docker unpause %s", strings.Join(ids, " ")))
+}
+
 // ContainerLogCmd returns the command to retrieve the log for a container based on ID
 func (r *Docker) ContainerLogCmd(id string, len int, follow bool) string {
 	var cmd strings.Builder
@@ -38,7 +38,7 @@ func init() {
 }
 
 func createHypervHost(config cfg.MachineConfig) interface{} {
-	d := hyperv.NewDriver(cfg.GetMachineName(), constants.GetMinipath())
+	d := hyperv.NewDriver(config.MachineName(), constants.GetMinipath())
 
 	d.Boot2DockerURL = config.Downloader.GetISOFileURI(config.MinikubeISO)
 	d.VSwitch = config.HypervVirtualSwitch
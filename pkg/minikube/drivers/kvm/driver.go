@@ -56,7 +56,7 @@ type kvmDriver struct {
 func createKVMHost(config cfg.MachineConfig) interface{} {
 	return &kvmDriver{
 		BaseDriver: &drivers.BaseDriver{
-			MachineName: cfg.GetMachineName(),
+			MachineName: config.MachineName(),
 			StorePath:   constants.GetMinipath(),
 			SSHUser:     "docker",
 		},
@@ -66,8 +66,8 @@ func createKVMHost(config cfg.MachineConfig) interface{} {
 		PrivateNetwork: "docker-machines",
 		Boot2DockerURL: config.Downloader.GetISOFileURI(config.MinikubeISO),
 		DiskSize:       config.DiskSize,
-		DiskPath:       filepath.Join(constants.GetMinipath(), "machines", cfg.GetMachineName(), fmt.Sprintf("%s.rawdisk", cfg.GetMachineName())),
-		ISO:            filepath.Join(constants.GetMinipath(), "machines", cfg.GetMachineName(), "boot2docker.iso"),
+		DiskPath:       filepath.Join(constants.GetMinipath(), "machines", config.MachineName(), fmt.Sprintf("%s.rawdisk", config.MachineName())),
+		ISO:            filepath.Join(constants.GetMinipath(), "machines", config.MachineName(), "boot2docker.iso"),
 		CacheMode:      "default",
 		IOMode:         "threads",
 	}
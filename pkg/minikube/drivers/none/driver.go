@@ -40,7 +40,7 @@ func init() {
 // createNoneHost creates a none Driver from a MachineConfig
 func createNoneHost(config cfg.MachineConfig) interface{} {
 	return none.NewDriver(none.Config{
-		MachineName:      cfg.GetMachineName(),
+		MachineName:      config.MachineName(),
 		StorePath:        constants.GetMinipath(),
 		ContainerRuntime: config.ContainerRuntime,
 	})
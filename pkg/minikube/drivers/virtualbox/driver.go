@@ -38,7 +38,7 @@ func init() {
 }
 
 func createVirtualboxHost(config cfg.MachineConfig) interface{} {
-	d := virtualbox.NewDriver(cfg.GetMachineName(), constants.GetMinipath())
+	d := virtualbox.NewDriver(config.MachineName(), constants.GetMinipath())
 
 	d.Boot2DockerURL = config.Downloader.GetISOFileURI(config.MinikubeISO)
 	d.Memory = config.Memory
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressdns
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The subset of the DNS wire format (RFC 1035) needed to answer A queries
+const (
+	headerLen = 12
+
+	typeA    = 1
+	typeAny  = 255
+	classIN  = 1
+	classAny = 255
+
+	flagResponse      = 1 << 15
+	flagAuthoritative = 1 << 10
+	flagRecursion     = 1 << 8
+	opcodeMask        = 0xf << 11
+
+	rcodeSuccess        = 0
+	rcodeFormatError    = 1
+	rcodeNameError      = 3
+	rcodeNotImplemented = 4
+
+	// ttl is short, as ingresses come and go
+	ttl = 30
+)
+
+// question is the question of a DNS query
+type question struct {
+	name  string
+	qtype uint16
+	class uint16
+	// raw is the question as sent, which is echoed in the response
+	raw []byte
+}
+
+// parseQuery returns the question of a query, which must contain exactly one
+func parseQuery(msg []byte) (*question, error) {
+	if len(msg) < headerLen {
+		return nil, errors.New("message too short")
+	}
+	if binary.BigEndian.Uint16(msg[4:]) != 1 {
+		return nil, errors.New("expected exactly one question")
+	}
+
+	var labels []string
+	off := headerLen
+	for {
+		if off >= len(msg) {
+			return nil, errors.New("truncated name")
+		}
+		l := int(msg[off])
+		off++
+		if l == 0 {
+			break
+		}
+		// queries have a single name, so there is nothing to compress
+		if l&0xc0 != 0 {
+			return nil, errors.New("unexpected compressed name")
+		}
+		if off+l > len(msg) {
+			return nil, errors.New("truncated label")
+		}
+		labels = append(labels, string(msg[off:off+l]))
+		off += l
+	}
+	if off+4 > len(msg) {
+		return nil, errors.New("truncated question")
+	}
+	return &question{
+		name:  strings.ToLower(strings.Join(labels, ".")),
+		qtype: binary.BigEndian.Uint16(msg[off:]),
+		class: binary.BigEndian.Uint16(msg[off+2:]),
+		raw:   msg[headerLen : off+4],
+	}, nil
+}
+
+// response builds the response to a query, with an A record for the IP if it is not nil
+func response(query []byte, q *question, rcode uint16, ip net.IP) []byte {
+	flags := binary.BigEndian.Uint16(query[2:])
+	flags = flagResponse | flagAuthoritative | flags&(opcodeMask|flagRecursion) | rcode
+
+	msg := make([]byte, headerLen, 512)
+	copy(msg, query[:2])
+	binary.BigEndian.PutUint16(msg[2:], flags)
+	if q == nil {
+		return msg
+	}
+	binary.BigEndian.PutUint16(msg[4:], 1)
+	msg = append(msg, q.raw...)
+	if ip == nil {
+		return msg
+	}
+
+	binary.BigEndian.PutUint16(msg[6:], 1)
+	// a pointer to the name of the question, which follows the header
+	msg = append(msg, 0xc0, headerLen)
+	var rr [10]byte
+	binary.BigEndian.PutUint16(rr[0:], typeA)
+	binary.BigEndian.PutUint16(rr[2:], classIN)
+	binary.BigEndian.PutUint32(rr[4:], ttl)
+	binary.BigEndian.PutUint16(rr[8:], net.IPv4len)
+	msg = append(msg, rr[:]...)
+	return append(msg, ip.To4()...)
+}
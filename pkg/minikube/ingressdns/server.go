@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressdns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// Server answers DNS queries for the hosts of Ingress objects
+type Server struct {
+	mu    sync.RWMutex
+	hosts map[string]net.IP
+}
+
+// NewServer returns a server which knows no hosts yet
+func NewServer() *Server {
+	return &Server{hosts: map[string]net.IP{}}
+}
+
+// SetHosts replaces the hosts the server answers for. Hosts may start with a "*." wildcard label.
+func (s *Server) SetHosts(hosts map[string]net.IP) {
+	lower := make(map[string]net.IP, len(hosts))
+	for h, ip := range hosts {
+		lower[strings.ToLower(strings.TrimSuffix(h, "."))] = ip
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hosts = lower
+}
+
+// lookup returns the IP of a host, matching wildcards one label deep
+func (s *Server) lookup(name string) (net.IP, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if ip, ok := s.hosts[name]; ok {
+		return ip, true
+	}
+	if i := strings.Index(name, "."); i != -1 {
+		ip, ok := s.hosts["*"+name[i:]]
+		return ip, ok
+	}
+	return nil, false
+}
+
+// answer returns the response to a query, or nil if the query should be dropped
+func (s *Server) answer(query []byte) []byte {
+	if len(query) < headerLen || query[2]&0x80 != 0 {
+		return nil
+	}
+	q, err := parseQuery(query)
+	if err != nil {
+		glog.V(3).Infof("invalid query: %v", err)
+		return response(query, nil, rcodeFormatError, nil)
+	}
+	if uint16(query[2])<<8&opcodeMask != 0 {
+		return response(query, q, rcodeNotImplemented, nil)
+	}
+	ip, ok := s.lookup(q.name)
+	glog.V(4).Infof("query %s (type %d): %s", q.name, q.qtype, ip)
+	if !ok {
+		return response(query, q, rcodeNameError, nil)
+	}
+	if (q.qtype != typeA && q.qtype != typeAny) || (q.class != classIN && q.class != classAny) || ip.To4() == nil {
+		// the name exists, but has no records of the type
+		return response(query, q, rcodeSuccess, nil)
+	}
+	return response(query, q, rcodeSuccess, ip)
+}
+
+// Serve answers DNS queries on the connection until the context is done
+func (s *Server) Serve(ctx context.Context, conn net.PacketConn) error {
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	buf := make([]byte, 512)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "read")
+		}
+		resp := s.answer(buf[:n])
+		if resp == nil {
+			continue
+		}
+		if _, err := conn.WriteTo(resp, from); err != nil {
+			glog.Warningf("error answering %s: %v", from, err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressdns
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newQuery builds a query with a single question, and recursion desired
+func newQuery(id uint16, name string, qtype uint16) []byte {
+	msg := make([]byte, headerLen)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], flagRecursion)
+	binary.BigEndian.PutUint16(msg[4:], 1)
+	for _, label := range strings.Split(name, ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0, 0, byte(qtype), 0, classIN)
+	return msg
+}
+
+func rcode(resp []byte) int {
+	return int(binary.BigEndian.Uint16(resp[2:]) & 0xf)
+}
+
+func answers(resp []byte) int {
+	return int(binary.BigEndian.Uint16(resp[6:]))
+}
+
+func TestAnswer(t *testing.T) {
+	s := NewServer()
+	s.SetHosts(map[string]net.IP{
+		"hello.test":    net.ParseIP("192.168.99.100"),
+		"*.apps.test":   net.ParseIP("192.168.99.100"),
+		"Upper.Test.":   net.ParseIP("192.168.99.101"),
+		"ipv6only.test": net.ParseIP("fd00::1"),
+	})
+
+	tcs := []struct {
+		name    string
+		qtype   uint16
+		rcode   int
+		answers int
+		ip      string
+	}{
+		{name: "hello.test", qtype: typeA, rcode: rcodeSuccess, answers: 1, ip: "192.168.99.100"},
+		{name: "HELLO.test", qtype: typeAny, rcode: rcodeSuccess, answers: 1, ip: "192.168.99.100"},
+		{name: "upper.test", qtype: typeA, rcode: rcodeSuccess, answers: 1, ip: "192.168.99.101"},
+		{name: "foo.apps.test", qtype: typeA, rcode: rcodeSuccess, answers: 1, ip: "192.168.99.100"},
+		{name: "foo.bar.apps.test", qtype: typeA, rcode: rcodeNameError},
+		{name: "hello.test", qtype: 28, rcode: rcodeSuccess},
+		{name: "ipv6only.test", qtype: typeA, rcode: rcodeSuccess},
+		{name: "unknown.test", qtype: typeA, rcode: rcodeNameError},
+	}
+	for i, tc := range tcs {
+		query := newQuery(uint16(i), tc.name, tc.qtype)
+		resp := s.answer(query)
+		if binary.BigEndian.Uint16(resp) != uint16(i) {
+			t.Errorf("%s: response ID %d, expected %d", tc.name, binary.BigEndian.Uint16(resp), i)
+		}
+		if binary.BigEndian.Uint16(resp[2:])&(flagResponse|flagRecursion) != flagResponse|flagRecursion {
+			t.Errorf("%s: expected the response and recursion desired flags, got %x", tc.name, resp[2:4])
+		}
+		if rcode(resp) != tc.rcode || answers(resp) != tc.answers {
+			t.Errorf("%s: rcode %d with %d answers, expected %d with %d", tc.name, rcode(resp), answers(resp), tc.rcode, tc.answers)
+			continue
+		}
+		if tc.answers == 1 {
+			// the answer follows the echoed question
+			ip := net.IP(resp[len(query)+12:])
+			if !ip.Equal(net.ParseIP(tc.ip)) {
+				t.Errorf("%s: answered %s, expected %s", tc.name, ip, tc.ip)
+			}
+		}
+	}
+}
+
+func TestAnswerInvalid(t *testing.T) {
+	s := NewServer()
+	if resp := s.answer([]byte{1, 2, 3}); resp != nil {
+		t.Errorf("expected short messages to be dropped, got %v", resp)
+	}
+	truncated := newQuery(1, "hello.test", typeA)
+	truncated = truncated[:len(truncated)-3]
+	if resp := s.answer(truncated); rcode(resp) != rcodeFormatError {
+		t.Errorf("expected format error, got rcode %d", rcode(resp))
+	}
+	response := newQuery(1, "hello.test", typeA)
+	response[2] |= 0x80
+	if resp := s.answer(response); resp != nil {
+		t.Errorf("expected responses to be dropped, got %v", resp)
+	}
+}
+
+func TestServe(t *testing.T) {
+	s := NewServer()
+	s.SetHosts(map[string]net.IP{"hello.test": net.ParseIP("192.168.99.100")})
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(ctx, conn)
+	}()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	query := newQuery(42, "hello.test", typeA)
+	if _, err := client.Write(query); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 512)
+	n, err := client.Read(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if resp := buf[:n]; rcode(resp) != rcodeSuccess || answers(resp) != 1 {
+		t.Errorf("unexpected response: %v", resp)
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Serve did not return after the context was done")
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressdns
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	extensionsv1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+)
+
+// retryInterval is how long to wait before listing ingresses again after an error
+var retryInterval = 5 * time.Second
+
+// hostsOf returns the hosts of the rules of the ingresses, all resolving to the IP
+func hostsOf(ingresses map[string]v1beta1.Ingress, ip net.IP) map[string]net.IP {
+	hosts := map[string]net.IP{}
+	for _, ing := range ingresses {
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host != "" {
+				hosts[rule.Host] = ip
+			}
+		}
+	}
+	return hosts
+}
+
+// WatchIngresses keeps the hosts of the server up to date with the Ingress objects of the cluster,
+// resolving them to the IP of the node, until the context is done
+func WatchIngresses(ctx context.Context, client extensionsv1beta1.IngressesGetter, ip net.IP, s *Server) {
+	for {
+		if err := watchIngresses(ctx, client, ip, s); err != nil {
+			glog.Warningf("error watching ingresses, retrying in %s: %v", retryInterval, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// watchIngresses lists the ingresses and follows their changes, until the watch ends or the context is done
+func watchIngresses(ctx context.Context, client extensionsv1beta1.IngressesGetter, ip net.IP, s *Server) error {
+	list, err := client.Ingresses("").List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	ingresses := map[string]v1beta1.Ingress{}
+	for _, ing := range list.Items {
+		ingresses[ing.Namespace+"/"+ing.Name] = ing
+	}
+	s.SetHosts(hostsOf(ingresses, ip))
+
+	w, err := client.Ingresses("").Watch(metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				glog.V(3).Info("ingress watch ended, listing again")
+				return nil
+			}
+			ing, ok := ev.Object.(*v1beta1.Ingress)
+			if !ok {
+				// e.g. an error event, after which the watch ends
+				glog.V(3).Infof("unexpected ingress watch event: %v", ev.Object)
+				continue
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				ingresses[ing.Namespace+"/"+ing.Name] = *ing
+			case watch.Deleted:
+				delete(ingresses, ing.Namespace+"/"+ing.Name)
+			}
+			s.SetHosts(hostsOf(ingresses, ip))
+		}
+	}
+}
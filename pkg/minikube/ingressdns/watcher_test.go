@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressdns
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	extensionsv1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+)
+
+func ingress(name string, hosts ...string) *v1beta1.Ingress {
+	ing := &v1beta1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	for _, h := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, v1beta1.IngressRule{Host: h})
+	}
+	return ing
+}
+
+// waitFor polls the server until the host resolves as expected
+func waitFor(t *testing.T, s *Server, host string, expected bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := s.lookup(host); ok == expected {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("timed out waiting for %s to resolve: %t", host, expected)
+}
+
+type stubIngresses struct {
+	extensionsv1beta1.IngressInterface
+	list    *v1beta1.IngressList
+	watcher *watch.FakeWatcher
+}
+
+func (s *stubIngresses) List(opts metav1.ListOptions) (*v1beta1.IngressList, error) {
+	return s.list, nil
+}
+
+func (s *stubIngresses) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	return s.watcher, nil
+}
+
+type stubIngressesGetter struct {
+	ingresses *stubIngresses
+}
+
+func (g *stubIngressesGetter) Ingresses(namespace string) extensionsv1beta1.IngressInterface {
+	return g.ingresses
+}
+
+func TestWatchIngresses(t *testing.T) {
+	ingresses := &stubIngresses{
+		list: &v1beta1.IngressList{
+			Items: []v1beta1.Ingress{*ingress("existing", "existing.test", "")},
+		},
+		watcher: watch.NewFake(),
+	}
+
+	s := NewServer()
+	ip := net.ParseIP("192.168.99.100")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go WatchIngresses(ctx, &stubIngressesGetter{ingresses}, ip, s)
+
+	waitFor(t, s, "existing.test", true)
+	ingresses.watcher.Add(ingress("added", "added.test"))
+	waitFor(t, s, "added.test", true)
+	ingresses.watcher.Delete(ingress("existing", "existing.test"))
+	waitFor(t, s, "existing.test", false)
+	if got, _ := s.lookup("added.test"); !got.Equal(ip) {
+		t.Errorf("added.test resolved to %s, expected %s", got, ip)
+	}
+	if _, ok := s.lookup(""); ok {
+		t.Errorf("expected rules without a host to be ignored")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+// BuildImage ships a build context from the host to a machine, and builds an image from it with the container runtime.
+// file is the path of the Dockerfile, relative to the context directory.
+func BuildImage(cr bootstrapper.CommandRunner, r cruntime.Manager, contextDir string, file string, tag string) error {
+	tf, err := ioutil.TempFile("", "minikube-build-context.*.tar")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+	if err := writeContextTar(contextDir, tf); err != nil {
+		tf.Close()
+		return errors.Wrap(err, "archiving build context")
+	}
+	if err := tf.Close(); err != nil {
+		return err
+	}
+
+	filename := filepath.Base(tf.Name())
+	f, err := assets.NewFileAsset(tf.Name(), tempLoadDir, filename, "0644")
+	if err != nil {
+		return errors.Wrapf(err, "creating copyable file asset: %s", filename)
+	}
+	if err := cr.Copy(f); err != nil {
+		return errors.Wrap(err, "transferring build context")
+	}
+
+	archive := path.Join(tempLoadDir, filename)
+	dir := strings.TrimSuffix(archive, ".tar")
+	defer func() {
+		if err := cr.Run(fmt.Sprintf("sudo rm -rf %s %s", dir, archive)); err != nil {
+			glog.Warningf("unable to remove build context: %v", err)
+		}
+	}()
+	if err := cr.Run(fmt.Sprintf("sudo mkdir -p %s && sudo tar -C %s -xf %s", dir, dir, archive)); err != nil {
+		return errors.Wrap(err, "extracting build context")
+	}
+	return r.BuildImage(dir, filepath.ToSlash(file), tag)
+}
+
+// writeContextTar writes the contents of a directory as a tar archive
+func writeContextTar(dir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
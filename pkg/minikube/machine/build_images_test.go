@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteContextTar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minikube-build-test")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"Dockerfile":     "FROM busybox\nCOPY app /app\n",
+		"app/main.sh":    "echo hello\n",
+		"app/data/a.txt": "a",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := writeContextTar(dir, &b); err != nil {
+		t.Fatalf("writeContextTar: %v", err)
+	}
+
+	got := map[string]string{}
+	tr := tar.NewReader(&b)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading archive: %v", err)
+		}
+		var content bytes.Buffer
+		if _, err := io.Copy(&content, tr); err != nil {
+			t.Fatalf("reading %s: %v", hdr.Name, err)
+		}
+		got[hdr.Name] = content.String()
+	}
+
+	want := map[string]string{
+		"Dockerfile":     files["Dockerfile"],
+		"app/":           "",
+		"app/main.sh":    files["app/main.sh"],
+		"app/data/":      "",
+		"app/data/a.txt": files["app/data/a.txt"],
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("writeContextTar() unexpected archive contents, diff (-got +want): %s", diff)
+	}
+}
@@ -52,9 +52,26 @@ func CacheBinariesForBootstrapper(version string, clusterBootstrapper string) er
 // CacheBinary will cache a binary on the host
 func CacheBinary(binary, version, osName, archName string) (string, error) {
 	targetDir := constants.MakeMiniPath("cache", version)
+	// kubectl is cached for the host too, by "minikube kubectl"
+	if binary == "kubectl" && osName != runtime.GOOS {
+		targetDir = constants.MakeMiniPath("cache", osName, version)
+	}
 	targetFilepath := path.Join(targetDir, binary)
 
 	url := constants.GetKubernetesReleaseURL(binary, version, osName, archName)
+	checksum := constants.GetKubernetesReleaseURLSHA1(binary, version, osName, archName)
+	checksumHash := crypto.SHA1
+	// k3s is released on its own, with the Kubernetes version built in
+	if binary == "k3s" {
+		var err error
+		if url, err = constants.GetK3sReleaseURL(version, archName); err != nil {
+			return "", err
+		}
+		if checksum, err = constants.GetK3sReleaseURLSHA256(version, archName); err != nil {
+			return "", err
+		}
+		checksumHash = crypto.SHA256
+	}
 
 	_, err := os.Stat(targetFilepath)
 	// If it exists, do no verification and continue
@@ -74,8 +91,8 @@ func CacheBinary(binary, version, osName, archName string) (string, error) {
 		Mkdirs: download.MkdirAll,
 	}
 
-	options.Checksum = constants.GetKubernetesReleaseURLSHA1(binary, version, osName, archName)
-	options.ChecksumHash = crypto.SHA1
+	options.Checksum = checksum
+	options.ChecksumHash = checksumHash
 
 	console.OutStyle("file-download", "Downloading %s %s", binary, version)
 	if err := download.ToFile(url, targetFilepath, options); err != nil {
@@ -91,7 +108,12 @@ func CacheBinary(binary, version, osName, archName string) (string, error) {
 
 // CopyBinary copies previously cached binaries into the path
 func CopyBinary(cr bootstrapper.CommandRunner, binary, path string) error {
-	f, err := assets.NewFileAsset(path, "/usr/bin", binary, "0755")
+	return CopyBinaryToDir(cr, binary, path, "/usr/bin")
+}
+
+// CopyBinaryToDir copies a previously cached binary into a directory of the node
+func CopyBinaryToDir(cr bootstrapper.CommandRunner, binary, path, dir string) error {
+	f, err := assets.NewFileAsset(path, dir, binary, "0755")
 	if err != nil {
 		return errors.Wrap(err, "new file asset")
 	}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// digestSuffix is appended to the path of a cached image to get the path of its recorded digest
+const digestSuffix = ".sha256"
+
+// writeDigest records the sha256 digest of a cached image, replacing the previous one atomically
+func writeDigest(path string, sum []byte) error {
+	tf, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+digestSuffix+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+	if _, err := fmt.Fprintln(tf, hex.EncodeToString(sum)); err != nil {
+		tf.Close()
+		return err
+	}
+	if err := tf.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tf.Name(), path+digestSuffix)
+}
+
+// fileDigest returns the sha256 digest of a file
+func fileDigest(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// VerifyCachedImage checks a cached image against its recorded digest.
+// Images cached before digests were recorded are checked to be complete tarballs, and their digest is recorded.
+func VerifyCachedImage(path string) error {
+	sum, err := fileDigest(path)
+	if err != nil {
+		return err
+	}
+	recorded, err := ioutil.ReadFile(path + digestSuffix)
+	if os.IsNotExist(err) {
+		if err := checkTarball(path); err != nil {
+			return errors.Wrap(err, "invalid tarball")
+		}
+		glog.Infof("recording digest of %s", path)
+		return writeDigest(path, sum)
+	}
+	if err != nil {
+		return err
+	}
+	if want := strings.TrimSpace(string(recorded)); want != hex.EncodeToString(sum) {
+		return fmt.Errorf("digest mismatch: recorded %s, got %s", want, hex.EncodeToString(sum))
+	}
+	return nil
+}
+
+// checkTarball reads every entry of a tarball, which fails if it is truncated
+func checkTarball(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	entries := 0
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(ioutil.Discard, tr); err != nil {
+			return err
+		}
+		entries++
+	}
+	if entries == 0 {
+		return fmt.Errorf("no entries")
+	}
+	return nil
+}
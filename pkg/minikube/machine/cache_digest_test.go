@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestTarball(t *testing.T, path string) []byte {
+	var b bytes.Buffer
+	tw := tar.NewWriter(&b)
+	content := bytes.Repeat([]byte("layer"), 1024)
+	if err := tw.WriteHeader(&tar.Header{Name: "layer.tar", Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("tar header: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("tar write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	if err := ioutil.WriteFile(path, b.Bytes(), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return b.Bytes()
+}
+
+func TestVerifyCachedImage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minikube-cache-digest-test")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "busybox_latest")
+
+	if err := VerifyCachedImage(path); !os.IsNotExist(err) {
+		t.Errorf("VerifyCachedImage() of a missing image = %v, want not exist", err)
+	}
+
+	// Images cached by older versions have no digest: it is recorded if the tarball is complete
+	data := writeTestTarball(t, path)
+	if err := VerifyCachedImage(path); err != nil {
+		t.Fatalf("VerifyCachedImage() of a legacy image: %v", err)
+	}
+	if _, err := os.Stat(path + digestSuffix); err != nil {
+		t.Errorf("digest was not recorded: %v", err)
+	}
+	if err := VerifyCachedImage(path); err != nil {
+		t.Errorf("VerifyCachedImage() with recorded digest: %v", err)
+	}
+
+	// A modified image no longer matches its digest
+	if err := ioutil.WriteFile(path, data[:len(data)/2], 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := VerifyCachedImage(path); err == nil {
+		t.Errorf("VerifyCachedImage() of a truncated image succeeded, want error")
+	}
+
+	// A truncated legacy image is detected without a digest
+	if err := os.Remove(path + digestSuffix); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := VerifyCachedImage(path); err == nil {
+		t.Errorf("VerifyCachedImage() of a truncated legacy image succeeded, want error")
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// PruneImageCache removes the images of a cache directory which are not in keep, along with leftover partial downloads.
+// It returns the removed files and their total size. With dryRun, the files are only reported.
+func PruneImageCache(cacheDir string, keep []string, dryRun bool) ([]string, int64, error) {
+	kept := map[string]bool{}
+	for _, image := range keep {
+		kept[sanitizeCacheDir(filepath.Join(cacheDir, image))] = true
+	}
+
+	var unused []string
+	var size int64
+	err := filepath.Walk(cacheDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		image := strings.TrimSuffix(path, digestSuffix)
+		if kept[image] && !strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+		unused = append(unused, path)
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if dryRun {
+		return unused, size, nil
+	}
+
+	for _, path := range unused {
+		glog.Infof("Removing unused cache file %s", path)
+		if err := os.Remove(path); err != nil {
+			return nil, 0, err
+		}
+	}
+	return unused, size, cleanImageCacheDir(cacheDir)
+}
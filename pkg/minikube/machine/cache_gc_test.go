@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPruneImageCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minikube-cache-gc-test")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{
+		"k8s.gcr.io/pause_3.1",
+		"k8s.gcr.io/pause_3.1.sha256",
+		"k8s.gcr.io/etcd_3.3.10",
+		"k8s.gcr.io/etcd_3.3.10.sha256",
+		"k8s.gcr.io/kube-proxy_v1.14.0.123456.tmp",
+		"redis_3",
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte("data"), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	keep := []string{"k8s.gcr.io/pause:3.1", "redis:3"}
+	want := []string{
+		filepath.Join(dir, "k8s.gcr.io", "etcd_3.3.10"),
+		filepath.Join(dir, "k8s.gcr.io", "etcd_3.3.10.sha256"),
+		filepath.Join(dir, "k8s.gcr.io", "kube-proxy_v1.14.0.123456.tmp"),
+	}
+
+	removed, size, err := PruneImageCache(dir, keep, true)
+	if err != nil {
+		t.Fatalf("PruneImageCache(dryRun): %v", err)
+	}
+	sort.Strings(removed)
+	if diff := cmp.Diff(removed, want); diff != "" {
+		t.Errorf("PruneImageCache(dryRun) unexpected results, diff (-got +want): %s", diff)
+	}
+	if size != int64(len(want)*len("data")) {
+		t.Errorf("PruneImageCache(dryRun) size = %d, want %d", size, len(want)*len("data"))
+	}
+	for _, p := range want {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("dry run removed %s", p)
+		}
+	}
+
+	if _, _, err := PruneImageCache(dir, keep, false); err != nil {
+		t.Fatalf("PruneImageCache: %v", err)
+	}
+	for _, p := range want {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed", p)
+		}
+	}
+	for _, f := range []string{"k8s.gcr.io/pause_3.1", "k8s.gcr.io/pause_3.1.sha256", "redis_3"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f))); err != nil {
+			t.Errorf("%s was removed: %v", f, err)
+		}
+	}
+}
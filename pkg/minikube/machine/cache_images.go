@@ -18,6 +18,7 @@ package machine
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"io"
 	"io/ioutil"
 	"log"
@@ -100,6 +101,15 @@ func LoadImages(cmd bootstrapper.CommandRunner, images []string, cacheDir string
 		g.Go(func() error {
 			src := filepath.Join(cacheDir, image)
 			src = sanitizeCacheDir(src)
+			if err := VerifyCachedImage(src); err != nil && !os.IsNotExist(err) {
+				glog.Warningf("Cached image %s is corrupt, caching it again: %v", src, err)
+				if err := os.Remove(src); err != nil {
+					return errors.Wrapf(err, "removing corrupt image %s", src)
+				}
+				if err := CacheImage(image, src); err != nil {
+					return errors.Wrapf(err, "caching image %s", src)
+				}
+			}
 			if err := loadImageFromCache(cmd, cc.KubernetesConfig, src); err != nil {
 				glog.Warningf("Failed to load %s: %v", src, err)
 				return errors.Wrapf(err, "loading image %s", src)
@@ -141,6 +151,11 @@ func CacheAndLoadImages(images []string) error {
 	return LoadImages(cmdRunner, images, constants.ImageCacheDir)
 }
 
+// CachedImagePath returns the path of an image in the image cache
+func CachedImagePath(image string) string {
+	return sanitizeCacheDir(filepath.Join(constants.ImageCacheDir, image))
+}
+
 // # ParseReference cannot have a : in the directory path
 func sanitizeCacheDir(image string) string {
 	if runtime.GOOS == "windows" && hasWindowsDriveLetter(image) {
@@ -205,6 +220,19 @@ func getWindowsVolumeNameCmd(d string) (string, error) {
 // loadImageFromCache loads a single image from the cache
 func loadImageFromCache(cr bootstrapper.CommandRunner, k8s config.KubernetesConfig, src string) error {
 	glog.Infof("Loading image from cache: %s", src)
+	r, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: cr})
+	if err != nil {
+		return errors.Wrap(err, "runtime")
+	}
+	if err := LoadImageFile(cr, r, src); err != nil {
+		return err
+	}
+	glog.Infof("Successfully loaded image %s from cache", src)
+	return nil
+}
+
+// LoadImageFile copies an image tarball from the host to a machine, and loads it into its container runtime
+func LoadImageFile(cr bootstrapper.CommandRunner, r cruntime.Manager, src string) error {
 	filename := filepath.Base(src)
 	if _, err := os.Stat(src); err != nil {
 		return err
@@ -215,13 +243,9 @@ func loadImageFromCache(cr bootstrapper.CommandRunner, k8s config.KubernetesConf
 		return errors.Wrapf(err, "creating copyable file asset: %s", filename)
 	}
 	if err := cr.Copy(f); err != nil {
-		return errors.Wrap(err, "transferring cached image")
+		return errors.Wrap(err, "transferring image")
 	}
 
-	r, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: cr})
-	if err != nil {
-		return errors.Wrap(err, "runtime")
-	}
 	loadImageLock.Lock()
 	defer loadImageLock.Unlock()
 
@@ -233,7 +257,6 @@ func loadImageFromCache(cr bootstrapper.CommandRunner, k8s config.KubernetesConf
 	if err := cr.Run("sudo rm -rf " + dst); err != nil {
 		return errors.Wrap(err, "deleting temp docker image location")
 	}
-	glog.Infof("Successfully loaded image %s from cache", src)
 	return nil
 }
 
@@ -246,12 +269,15 @@ func DeleteFromImageCacheDir(images []string) error {
 		if err := os.Remove(path); err != nil {
 			return err
 		}
+		if err := os.Remove(path + digestSuffix); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
-	return cleanImageCacheDir()
+	return cleanImageCacheDir(constants.ImageCacheDir)
 }
 
-func cleanImageCacheDir() error {
-	err := filepath.Walk(constants.ImageCacheDir, func(path string, info os.FileInfo, err error) error {
+func cleanImageCacheDir(cacheDir string) error {
+	err := filepath.Walk(cacheDir, func(path string, info os.FileInfo, err error) error {
 		// If error is not nil, it's because the path was already deleted and doesn't exist
 		// Move on to next path
 		if err != nil {
@@ -335,17 +361,35 @@ func CacheImage(image, dst string) error {
 	if err != nil {
 		return err
 	}
-	err = tarball.Write(ref, img, f)
+	// Don't leave partial downloads behind. This is a no-op once the file has been renamed.
+	defer os.Remove(f.Name())
+	h := sha256.New()
+	err = tarball.Write(ref, img, io.MultiWriter(f, h))
 	if err != nil {
+		f.Close()
 		return err
 	}
 	err = f.Close()
 	if err != nil {
 		return err
 	}
+	// The digest is recorded first, so that a cached image never exists without one
+	if err := writeDigest(dstPath, h.Sum(nil)); err != nil {
+		return errors.Wrap(err, "recording digest")
+	}
 	err = os.Rename(f.Name(), dstPath)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// SaveDaemonImage saves an image from the docker daemon of the host to a tarball
+func SaveDaemonImage(image, dst string) error {
+	glog.Infof("Saving image %s from the docker daemon to %s", image, dst)
+	out, err := exec.Command("docker", "save", "-o", dst, image).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "docker save %s: %s", image, out)
+	}
+	return nil
+}
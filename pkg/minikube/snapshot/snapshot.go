@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot stores snapshots of cluster state under the minikube home
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+const (
+	configFile  = "config.json"
+	archiveFile = "state.tar.gz"
+)
+
+// Snapshot describes a saved snapshot
+type Snapshot struct {
+	Name    string
+	Profile string
+	Created time.Time
+	// Size is the size of the state archive in bytes
+	Size int64
+}
+
+// Dir returns the directory holding the snapshots of a profile
+func Dir(profile string) string {
+	return constants.MakeMiniPath("snapshots", profile)
+}
+
+// validName returns an error if a snapshot name can not be used as a directory name
+func validName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid snapshot name: %q", name)
+	}
+	return nil
+}
+
+// Save stores the config and state archive of a profile as a named snapshot, replacing any existing one
+func Save(profile, name string, cc *config.Config, archive []byte) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cc, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "marshal config")
+	}
+	if err := os.MkdirAll(Dir(profile), 0700); err != nil {
+		return err
+	}
+
+	// Write to a temporary directory first, so that an interrupted save never leaves a partial snapshot
+	tmp, err := ioutil.TempDir(Dir(profile), "."+name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if err := ioutil.WriteFile(filepath.Join(tmp, configFile), data, 0600); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmp, archiveFile), archive, 0600); err != nil {
+		return err
+	}
+
+	dst := filepath.Join(Dir(profile), name)
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// Load returns the config and state archive of a named snapshot
+func Load(profile, name string) (*config.Config, []byte, error) {
+	if err := validName(name); err != nil {
+		return nil, nil, err
+	}
+	dir := filepath.Join(Dir(profile), name)
+	data, err := ioutil.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	var cc config.Config
+	if err := json.Unmarshal(data, &cc); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal config")
+	}
+	archive, err := ioutil.ReadFile(filepath.Join(dir, archiveFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cc, archive, nil
+}
+
+// Delete removes a named snapshot
+func Delete(profile, name string) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+	dir := filepath.Join(Dir(profile), name)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// List returns the snapshots of a profile, sorted by name
+func List(profile string) ([]Snapshot, error) {
+	files, err := ioutil.ReadDir(Dir(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var snaps []Snapshot
+	for _, f := range files {
+		if !f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		fi, err := os.Stat(filepath.Join(Dir(profile), f.Name(), archiveFile))
+		if err != nil {
+			continue
+		}
+		snaps = append(snaps, Snapshot{Name: f.Name(), Profile: profile, Created: fi.ModTime(), Size: fi.Size()})
+	}
+	return snaps, nil
+}
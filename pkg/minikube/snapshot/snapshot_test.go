@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func TestSaveLoadList(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "minikube-snapshot-test")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	os.Setenv(constants.MinikubeHome, tempDir)
+	defer os.Unsetenv(constants.MinikubeHome)
+
+	snaps, err := List("p1")
+	if err != nil || len(snaps) != 0 {
+		t.Fatalf("List() on empty home = %v, %v, want no snapshots", snaps, err)
+	}
+
+	cc := &config.Config{KubernetesConfig: config.KubernetesConfig{KubernetesVersion: "v1.14.1"}}
+	if err := Save("p1", "fixtures", cc, []byte("archive")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// Saving again replaces the snapshot
+	if err := Save("p1", "fixtures", cc, []byte("archive2")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, archive, err := Load("p1", "fixtures")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.KubernetesConfig.KubernetesVersion != "v1.14.1" {
+		t.Errorf("Load() KubernetesVersion = %q, want v1.14.1", got.KubernetesConfig.KubernetesVersion)
+	}
+	if string(archive) != "archive2" {
+		t.Errorf("Load() archive = %q, want archive2", archive)
+	}
+
+	snaps, err = List("p1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(snaps) != 1 || snaps[0].Name != "fixtures" || snaps[0].Size != int64(len("archive2")) {
+		t.Errorf("List() = %+v, want a single fixtures snapshot", snaps)
+	}
+
+	if err := Delete("p1", "fixtures"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := Load("p1", "fixtures"); !os.IsNotExist(err) {
+		t.Errorf("Load() after Delete error = %v, want not exist", err)
+	}
+}
+
+func TestInvalidName(t *testing.T) {
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`} {
+		if err := Save("p1", name, &config.Config{}, nil); err == nil {
+			t.Errorf("Save(%q) succeeded, want error", name)
+		}
+	}
+}
@@ -19,10 +19,12 @@ package tunnel
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/docker/machine/libmachine"
 	"github.com/docker/machine/libmachine/host"
 	"github.com/docker/machine/libmachine/state"
+	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -85,15 +87,30 @@ func getRoute(host *host.Host, clusterConfig config.Config) (*Route, error) {
 		return nil, errors.Wrapf(err, "error getting host IP for %s", host.Name)
 	}
 
-	_, ipNet, err := net.ParseCIDR(clusterConfig.KubernetesConfig.ServiceCIDR)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing service CIDR: %s", err)
-	}
 	ip := net.ParseIP(hostDriverIP)
 	if ip == nil {
 		return nil, fmt.Errorf("invalid IP for host %s", hostDriverIP)
 	}
 
+	//a dual-stack cluster has a service CIDR per family, only the one of the host IP's family is routable
+	var ipNet *net.IPNet
+	for _, cidr := range strings.Split(clusterConfig.KubernetesConfig.ServiceCIDR, ",") {
+		_, n, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing service CIDR: %s", err)
+		}
+		if (n.IP.To4() == nil) != (ip.To4() == nil) {
+			glog.Infof("skipping service CIDR %s: host IP %s is of another IP family", n, ip)
+			continue
+		}
+		if ipNet == nil {
+			ipNet = n
+		}
+	}
+	if ipNet == nil {
+		return nil, fmt.Errorf("no service CIDR in %q of the same IP family as the host IP %s", clusterConfig.KubernetesConfig.ServiceCIDR, ip)
+	}
+
 	return &Route{
 		Gateway:  ip,
 		DestCIDR: ipNet,
@@ -141,3 +141,48 @@ func TestRouteIPDetection(t *testing.T) {
 	}
 
 }
+
+func TestRouteDualStack(t *testing.T) {
+	tcs := []struct {
+		name         string
+		serviceCIDR  string
+		hostIP       string
+		expectedCIDR string
+	}{
+		{name: "IPv6 only", serviceCIDR: "fd00::/108", hostIP: "fd00:1::2", expectedCIDR: "fd00::/108"},
+		{name: "dual-stack, IPv4 host", serviceCIDR: "10.96.0.0/12,fd00::/108", hostIP: "192.168.1.1", expectedCIDR: "10.96.0.0/12"},
+		{name: "dual-stack, IPv6 host", serviceCIDR: "10.96.0.0/12, fd00::/108", hostIP: "fd00:1::2", expectedCIDR: "fd00::/108"},
+		{name: "no CIDR of the host's family", serviceCIDR: "fd00::/108", hostIP: "192.168.1.1"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Config{
+				KubernetesConfig: config.KubernetesConfig{
+					ServiceCIDR: tc.serviceCIDR,
+				},
+			}
+			h := &host.Host{
+				Driver: &tests.MockDriver{
+					IP: tc.hostIP,
+				},
+			}
+
+			route, err := getRoute(h, cfg)
+			if tc.expectedCIDR == "" {
+				if err == nil {
+					t.Errorf("expected an error, got route %s", route)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no errors but got: %s", err)
+			}
+			if route.DestCIDR.String() != tc.expectedCIDR {
+				t.Errorf("expected CIDR %s, got %s", tc.expectedCIDR, route.DestCIDR)
+			}
+			if route.Gateway.String() != tc.hostIP {
+				t.Errorf("expected gateway %s, got %s", tc.hostIP, route.Gateway)
+			}
+		})
+	}
+}
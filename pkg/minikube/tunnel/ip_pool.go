@@ -0,0 +1,272 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+//allocations maps machine names to the LoadBalancer IPs allocated to their services, keyed by namespace/name
+type allocations map[string]map[string]string
+
+//persistentAllocations stores the LoadBalancer IPs allocated to services, so that services keep
+//their IP across tunnel restarts. There is one file per user, shared across multiple vms.
+type persistentAllocations struct {
+	path string
+}
+
+const (
+	//allocationsLockTimeout is how long to wait for another tunnel to release the allocations
+	allocationsLockTimeout = 10 * time.Second
+	//allocationsLockStale is the age after which a lock is considered left over by a crashed tunnel
+	allocationsLockStale = 30 * time.Second
+)
+
+//lock takes the lock file next to the allocations, and returns a function which releases it
+func (a *persistentAllocations) lock() (func(), error) {
+	lockPath := a.path + ".lock"
+	deadline := time.Now().Add(allocationsLockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "creating %s", lockPath)
+		}
+		if fi, err := os.Stat(lockPath); err == nil && time.Since(fi.ModTime()) > allocationsLockStale {
+			glog.Infof("Removing stale lock %s", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+//update loads the allocations, modifies them with fn, and saves them if fn reports a change.
+//The lock keeps concurrent tunnels from overwriting each other's allocations.
+func (a *persistentAllocations) update(fn func(allocs allocations) bool) error {
+	unlock, err := a.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	allocs, err := a.load()
+	if err != nil {
+		return errors.Wrap(err, "loading allocations")
+	}
+	if !fn(allocs) {
+		return nil
+	}
+	return errors.Wrap(a.save(allocs), "saving allocations")
+}
+
+func (a *persistentAllocations) load() (allocations, error) {
+	allocs := allocations{}
+	data, err := ioutil.ReadFile(a.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return allocs, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return allocs, nil
+	}
+	if err := json.Unmarshal(data, &allocs); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", a.path)
+	}
+	return allocs, nil
+}
+
+//save writes the allocations to a temporary file first, so that readers never see a partial file
+func (a *persistentAllocations) save(allocs allocations) error {
+	data, err := json.Marshal(allocs)
+	if err != nil {
+		return err
+	}
+	tf, err := ioutil.TempFile(filepath.Dir(a.path), filepath.Base(a.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tf.Name())
+	if _, err := tf.Write(data); err != nil {
+		tf.Close()
+		return err
+	}
+	if err := tf.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tf.Name(), a.path)
+}
+
+//ReleaseExcept releases the IPs allocated for all machines except the given ones, and returns the released machines
+func (a *persistentAllocations) ReleaseExcept(keep map[string]bool) ([]string, error) {
+	var released []string
+	err := a.update(func(allocs allocations) bool {
+		for machineName := range allocs {
+			if !keep[machineName] {
+				released = append(released, machineName)
+				delete(allocs, machineName)
+			}
+		}
+		return len(released) > 0
+	})
+	if err != nil || len(released) == 0 {
+		return nil, err
+	}
+	sort.Strings(released)
+	return released, nil
+}
+
+//ipPool allocates stable IPs from a CIDR to the services of type LoadBalancer of a machine
+type ipPool struct {
+	cidr        *net.IPNet
+	machineName string
+	store       *persistentAllocations
+
+	//allocated holds the IPs of the services at the last sync
+	allocated map[string]string
+}
+
+func serviceKey(svc core_v1.Service) string {
+	return svc.Namespace + "/" + svc.Name
+}
+
+//sync allocates IPs to new services of type LoadBalancer, and releases the IPs of services which are gone
+func (p *ipPool) sync(services []core_v1.Service) error {
+	var wanted []string
+	isWanted := map[string]bool{}
+	for _, svc := range services {
+		if svc.Spec.Type == core_v1.ServiceTypeLoadBalancer {
+			wanted = append(wanted, serviceKey(svc))
+			isWanted[serviceKey(svc)] = true
+		}
+	}
+	sort.Strings(wanted)
+
+	var errs []string
+	next := map[string]string{}
+	err := p.store.update(func(allocs allocations) bool {
+		//IPs of other machines are not reused, in case they share the pool
+		used := map[string]bool{}
+		for machineName, ips := range allocs {
+			if machineName == p.machineName {
+				continue
+			}
+			for _, ip := range ips {
+				used[ip] = true
+			}
+		}
+
+		for svc, ip := range allocs[p.machineName] {
+			if !isWanted[svc] || !p.cidr.Contains(net.ParseIP(ip)) || used[ip] {
+				glog.Infof("Releasing LoadBalancer IP %s of %s", ip, svc)
+				continue
+			}
+			next[svc] = ip
+			used[ip] = true
+		}
+
+		for _, svc := range wanted {
+			if _, ok := next[svc]; ok {
+				continue
+			}
+			ip := p.free(used)
+			if ip == "" {
+				errs = append(errs, fmt.Sprintf("no LoadBalancer IP left in %s for %s", p.cidr, svc))
+				continue
+			}
+			glog.Infof("Allocated LoadBalancer IP %s to %s", ip, svc)
+			next[svc] = ip
+			used[ip] = true
+		}
+
+		if reflect.DeepEqual(next, allocs[p.machineName]) {
+			return false
+		}
+		if len(next) > 0 {
+			allocs[p.machineName] = next
+		} else {
+			delete(allocs, p.machineName)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	p.allocated = next
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+//ingressIP returns the IP allocated to a service, or "" if none is
+func (p *ipPool) ingressIP(svc core_v1.Service) string {
+	return p.allocated[serviceKey(svc)]
+}
+
+//free returns the first IP of the pool which is not used, skipping the network and broadcast addresses of IPv4 pools
+func (p *ipPool) free(used map[string]bool) string {
+	ones, bits := p.cidr.Mask.Size()
+	skipEnds := bits == 8*net.IPv4len && bits-ones > 1
+	for ip := p.cidr.IP.Mask(p.cidr.Mask); p.cidr.Contains(ip); ip = nextIP(ip) {
+		if skipEnds && (ip.Equal(p.cidr.IP.Mask(p.cidr.Mask)) || !p.cidr.Contains(nextIP(ip))) {
+			continue
+		}
+		if !used[ip.String()] {
+			return ip.String()
+		}
+	}
+	return ""
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+//overlaps returns whether two networks share any address
+func overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
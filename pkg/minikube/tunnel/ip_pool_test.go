@@ -0,0 +1,259 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/machine/libmachine/host"
+	"github.com/docker/machine/libmachine/state"
+	apiV1 "k8s.io/api/core/v1"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/tests"
+)
+
+func createTestAllocations(t *testing.T) (*persistentAllocations, func()) {
+	dir, err := ioutil.TempDir("", "allocations")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	return &persistentAllocations{path: filepath.Join(dir, "loadbalancer_ips.json")}, func() { os.RemoveAll(dir) }
+}
+
+func newTestPool(t *testing.T, cidr string, machineName string, store *persistentAllocations) *ipPool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatalf("parse %s: %v", cidr, err)
+	}
+	return &ipPool{cidr: ipNet, machineName: machineName, store: store}
+}
+
+func TestIPPoolSync(t *testing.T) {
+	store, cleanup := createTestAllocations(t)
+	defer cleanup()
+
+	pool := newTestPool(t, "172.30.0.0/24", "minikube", store)
+	a := lbService("default", "a", "10.96.0.10", 80)
+	b := lbService("default", "b", "10.96.0.11", 80)
+	c := lbService("other", "c", "10.96.0.12", 80)
+	notLB := lbService("default", "not-lb", "10.96.0.13", 80)
+	notLB.Spec.Type = apiV1.ServiceTypeClusterIP
+
+	if err := pool.sync([]apiV1.Service{b, a, notLB}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	expected := map[string]string{"default/a": "172.30.0.1", "default/b": "172.30.0.2"}
+	if !reflect.DeepEqual(pool.allocated, expected) {
+		t.Errorf("allocated = %v, expected %v", pool.allocated, expected)
+	}
+	if pool.ingressIP(notLB) != "" {
+		t.Errorf("expected no IP for %s, got %s", notLB.Name, pool.ingressIP(notLB))
+	}
+
+	//a new pool, e.g. after a tunnel restart, keeps the allocations, and reuses released IPs
+	pool = newTestPool(t, "172.30.0.0/24", "minikube", store)
+	if err := pool.sync([]apiV1.Service{b, c}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	expected = map[string]string{"default/b": "172.30.0.2", "other/c": "172.30.0.1"}
+	if !reflect.DeepEqual(pool.allocated, expected) {
+		t.Errorf("allocated = %v, expected %v", pool.allocated, expected)
+	}
+	allocs, err := store.load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(allocs["minikube"], expected) {
+		t.Errorf("persisted = %v, expected %v", allocs["minikube"], expected)
+	}
+
+	//other machines don't reuse the IPs
+	other := newTestPool(t, "172.30.0.0/24", "other", store)
+	if err := other.sync([]apiV1.Service{a}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if ip := other.ingressIP(a); ip != "172.30.0.3" {
+		t.Errorf("expected 172.30.0.3 for the other machine, got %s", ip)
+	}
+}
+
+func TestIPPoolExhausted(t *testing.T) {
+	store, cleanup := createTestAllocations(t)
+	defer cleanup()
+
+	//only 172.30.0.1 and 172.30.0.2 are usable
+	pool := newTestPool(t, "172.30.0.0/30", "minikube", store)
+	services := []apiV1.Service{
+		lbService("default", "a", "10.96.0.10", 80),
+		lbService("default", "b", "10.96.0.11", 80),
+		lbService("default", "c", "10.96.0.12", 80),
+	}
+	err := pool.sync(services)
+	if err == nil || !strings.Contains(err.Error(), "no LoadBalancer IP left in 172.30.0.0/30 for default/c") {
+		t.Errorf("expected pool exhaustion error, got: %v", err)
+	}
+	expected := map[string]string{"default/a": "172.30.0.1", "default/b": "172.30.0.2"}
+	if !reflect.DeepEqual(pool.allocated, expected) {
+		t.Errorf("allocated = %v, expected %v", pool.allocated, expected)
+	}
+}
+
+func TestReleaseExcept(t *testing.T) {
+	store, cleanup := createTestAllocations(t)
+	defer cleanup()
+
+	if err := store.save(allocations{
+		"running": {"default/a": "172.30.0.1"},
+		"stopped": {"default/a": "172.30.0.2"},
+	}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	released, err := store.ReleaseExcept(map[string]bool{"running": true})
+	if err != nil {
+		t.Fatalf("ReleaseExcept: %v", err)
+	}
+	if !reflect.DeepEqual(released, []string{"stopped"}) {
+		t.Errorf("released = %v, expected [stopped]", released)
+	}
+	allocs, err := store.load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := allocs["stopped"]; ok || len(allocs["running"]) != 1 {
+		t.Errorf("unexpected allocations after release: %v", allocs)
+	}
+}
+
+func TestConcurrentIPPoolSync(t *testing.T) {
+	store, cleanup := createTestAllocations(t)
+	defer cleanup()
+
+	//tunnels of different machines share the allocations file, and none of their allocations may be lost
+	machines := []string{"m1", "m2", "m3", "m4", "m5"}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(machines))
+	for _, machineName := range machines {
+		pool := newTestPool(t, "172.30.0.0/24", machineName, store)
+		wg.Add(1)
+		go func(pool *ipPool) {
+			defer wg.Done()
+			errs <- pool.sync([]apiV1.Service{lbService("default", "a", "10.96.0.10", 80)})
+		}(pool)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("sync: %v", err)
+		}
+	}
+
+	allocs, err := store.load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	used := map[string]bool{}
+	for _, machineName := range machines {
+		ip := allocs[machineName]["default/a"]
+		if ip == "" || used[ip] {
+			t.Errorf("machine %s has IP %q, allocations: %v", machineName, ip, allocs)
+		}
+		used[ip] = true
+	}
+	if _, err := os.Stat(store.path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("expected the lock to be released, got %v", err)
+	}
+}
+
+func TestTunnelWithIPPool(t *testing.T) {
+	machineName := "testmachine"
+	machineAPI := &tests.MockAPI{
+		FakeStore: tests.FakeStore{
+			Hosts: map[string]*host.Host{
+				machineName: {
+					Driver: &tests.MockDriver{
+						CurrentState: state.Running,
+						IP:           "192.168.99.100",
+					},
+				},
+			},
+		},
+	}
+	configLoader := &stubConfigLoader{
+		c: &config.Config{
+			KubernetesConfig: config.KubernetesConfig{
+				ServiceCIDR: "10.96.0.0/12",
+			}},
+	}
+	registry, cleanup := createTestRegistry(t)
+	defer cleanup()
+	store, cleanupAllocations := createTestAllocations(t)
+	defer cleanupAllocations()
+
+	router := &fakeRouter{}
+	tunnel, err := newTunnel(machineName, machineAPI, configLoader, newStubCoreClient(nil), registry, router)
+	if err != nil {
+		t.Fatalf("error creating tunnel: %v", err)
+	}
+	tunnel.reporter = &recordingReporter{}
+
+	_, overlapping, _ := net.ParseCIDR("10.100.0.0/24")
+	if err := tunnel.setIPPool(overlapping, store); err == nil {
+		t.Errorf("expected an error for a pool overlapping the service CIDR")
+	}
+	_, pool, _ := net.ParseCIDR("172.30.0.0/24")
+	if err := tunnel.setIPPool(pool, store); err != nil {
+		t.Fatalf("setIPPool: %v", err)
+	}
+
+	status := tunnel.update()
+	if status.RouteError != nil || status.LoadBalancerEmulatorError != nil {
+		t.Fatalf("unexpected errors: %s", status)
+	}
+	expectedRoutes := []*Route{
+		unsafeParseRoute("192.168.99.100", "10.96.0.0/12"),
+		unsafeParseRoute("192.168.99.100", "172.30.0.0/24"),
+	}
+	if len(router.rt) != len(expectedRoutes) {
+		t.Fatalf("expected routes %v, got %s", expectedRoutes, router.rt.String())
+	}
+	for i, r := range expectedRoutes {
+		if !router.rt[i].route.Equal(r) {
+			t.Errorf("expected route %s, got %s", r, router.rt[i].route)
+		}
+	}
+	tunnels, err := registry.List()
+	if err != nil || len(tunnels) != 2 {
+		t.Errorf("expected both routes to be registered, got %v, %v", tunnels, err)
+	}
+
+	tunnel.cleanup()
+	if len(router.rt) != 0 {
+		t.Errorf("expected routes to be cleaned up, got %s", router.rt.String())
+	}
+	tunnels, err = registry.List()
+	if err != nil || len(tunnels) != 0 {
+		t.Errorf("expected registry to be empty, got %v, %v", tunnels, err)
+	}
+}
@@ -37,11 +37,14 @@ type patchConverter interface {
 	convert(restClient rest.Interface, patch *Patch) *rest.Request
 }
 
-//loadBalancerEmulator is the main struct for emulating the loadbalancer behavior. it sets the ingress to the cluster IP
+//loadBalancerEmulator is the main struct for emulating the loadbalancer behavior. it sets the ingress to the IP
+//returned by ingressIP, which is the cluster IP unless the tunnel forwards the services in userspace
 type loadBalancerEmulator struct {
 	coreV1Client   v1.CoreV1Interface
 	requestSender  requestSender
 	patchConverter patchConverter
+	//ingressIP returns the IP a service is reachable on from the host, or "" if it is not reachable
+	ingressIP func(svc core_v1.Service) string
 }
 
 func (l *loadBalancerEmulator) PatchServices() ([]string, error) {
@@ -84,13 +87,16 @@ func (l *loadBalancerEmulator) applyOnLBServices(action func(restClient rest.Int
 	return managedServices, nil
 }
 func (l *loadBalancerEmulator) updateService(restClient rest.Interface, svc core_v1.Service) ([]byte, error) {
-	clusterIP := svc.Spec.ClusterIP
+	ip := l.ingressIP(svc)
+	if ip == "" {
+		return l.cleanupService(restClient, svc)
+	}
 	ingresses := svc.Status.LoadBalancer.Ingress
-	if len(ingresses) == 1 && ingresses[0].IP == clusterIP {
+	if len(ingresses) == 1 && ingresses[0].IP == ip {
 		return nil, nil
 	}
-	glog.V(3).Infof("[%s] setting ClusterIP as the LoadBalancer Ingress", svc.Name)
-	jsonPatch := fmt.Sprintf(`[{"op": "add", "path": "/status/loadBalancer/ingress", "value":  [ { "ip": "%s" } ] }]`, clusterIP)
+	glog.V(3).Infof("[%s] setting %s as the LoadBalancer Ingress", svc.Name, ip)
+	jsonPatch := fmt.Sprintf(`[{"op": "add", "path": "/status/loadBalancer/ingress", "value":  [ { "ip": "%s" } ] }]`, ip)
 	patch := &Patch{
 		Type:         k8s_types.JSONPatchType,
 		ResourceName: svc.Name,
@@ -103,9 +109,9 @@ func (l *loadBalancerEmulator) updateService(restClient rest.Interface, svc core
 	request := l.patchConverter.convert(restClient, patch)
 	result, err := l.requestSender.send(request)
 	if err != nil {
-		glog.Errorf("error patching %s with IP %s: %s", svc.Name, clusterIP, err)
+		glog.Errorf("error patching %s with IP %s: %s", svc.Name, ip, err)
 	} else {
-		glog.Infof("Patched %s with IP %s", svc.Name, clusterIP)
+		glog.Infof("Patched %s with IP %s", svc.Name, ip)
 	}
 	return result, err
 }
@@ -138,9 +144,14 @@ func newLoadBalancerEmulator(corev1Client v1.CoreV1Interface) loadBalancerEmulat
 		coreV1Client:   corev1Client,
 		requestSender:  &defaultRequestSender{},
 		patchConverter: &defaultPatchConverter{},
+		ingressIP:      clusterIP,
 	}
 }
 
+func clusterIP(svc core_v1.Service) string {
+	return svc.Spec.ClusterIP
+}
+
 type defaultPatchConverter struct{}
 
 func (c *defaultPatchConverter) convert(restClient rest.Interface, patch *Patch) *rest.Request {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
@@ -36,6 +37,8 @@ type ID struct {
 	//the rest is metadata
 	MachineName string
 	Pid         int
+	//PatchedServices are the LoadBalancer services last patched by the tunnel
+	PatchedServices []string `json:",omitempty"`
 }
 
 // Equal checks if two ID are equal
@@ -177,6 +180,30 @@ func (r *persistentRegistry) Remove(route *Route) (rerr error) {
 
 	return nil
 }
+
+//SetPatchedServices records the services patched by the tunnel owning a route, if they changed
+func (r *persistentRegistry) SetPatchedServices(tunnel *ID, services []string) error {
+	tunnels, err := r.List()
+	if err != nil {
+		return err
+	}
+	for _, t := range tunnels {
+		if !t.Route.Equal(tunnel.Route) || t.Pid != tunnel.Pid {
+			continue
+		}
+		if reflect.DeepEqual(t.PatchedServices, services) {
+			return nil
+		}
+		t.PatchedServices = services
+		bytes, err := json.Marshal(tunnels)
+		if err != nil {
+			return fmt.Errorf("error marshalling json %s", err)
+		}
+		return ioutil.WriteFile(r.path, bytes, 0600)
+	}
+	return nil
+}
+
 func (r *persistentRegistry) List() ([]*ID, error) {
 	f, err := os.Open(r.path)
 	if err != nil {
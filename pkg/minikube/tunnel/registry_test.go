@@ -143,6 +143,33 @@ func TestListAfterRegister(t *testing.T) {
 		t.Errorf("\nexpected %+v,\ngot      %+v", expectedList, tunnelList)
 	}
 }
+func TestListAfterRegisterIPv6(t *testing.T) {
+	file := tmpFile(t)
+	reg := &persistentRegistry{
+		path: file,
+	}
+	defer os.Remove(file)
+	id := &ID{
+		Route:       unsafeParseRoute("fd00:1::2", "fd00::/108"),
+		MachineName: "testmachine",
+		Pid:         1234,
+	}
+	if err := reg.Register(id); err != nil {
+		t.Errorf("failed to register: expected no error, got %s", err)
+	}
+
+	tunnelList, err := reg.List()
+	if err != nil {
+		t.Errorf("failed to list: expected no error, got %s", err)
+	}
+	if len(tunnelList) != 1 || !tunnelList[0].Equal(id) {
+		t.Errorf("\nexpected %+v,\ngot      %+v", id, tunnelList)
+	}
+	if len(tunnelList) == 1 && tunnelList[0].Route.String() != "fd00::/108 -> fd00:1::2" {
+		t.Errorf("expected the route to survive persistence, got %s", tunnelList[0].Route)
+	}
+}
+
 func TestRegisterRemoveList(t *testing.T) {
 	file := tmpFile(t)
 	reg := &persistentRegistry{
@@ -266,3 +293,32 @@ func createTestRegistry(t *testing.T) (reg *persistentRegistry, cleanup func())
 	}
 	return registry, func() { os.Remove(f.Name()) }
 }
+
+func TestSetPatchedServices(t *testing.T) {
+	reg, cleanup := createTestRegistry(t)
+	defer cleanup()
+
+	id := &ID{
+		Route:       unsafeParseRoute("1.2.3.4", "10.96.0.0/12"),
+		MachineName: "testmachine",
+		Pid:         os.Getpid(),
+	}
+	if err := reg.Register(id); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	other := &ID{Route: id.Route, MachineName: id.MachineName, Pid: 12341234}
+	if err := reg.SetPatchedServices(other, []string{"ignored"}); err != nil {
+		t.Fatalf("SetPatchedServices: %v", err)
+	}
+	if err := reg.SetPatchedServices(id, []string{"svc1", "svc2"}); err != nil {
+		t.Fatalf("SetPatchedServices: %v", err)
+	}
+
+	tunnels, err := reg.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tunnels) != 1 || !reflect.DeepEqual(tunnels[0].PatchedServices, []string{"svc1", "svc2"}) {
+		t.Errorf("expected the services of the owning tunnel to be recorded, got: %+v", tunnels)
+	}
+}
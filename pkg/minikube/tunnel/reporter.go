@@ -17,6 +17,7 @@ limitations under the License.
 package tunnel
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"io"
@@ -43,6 +44,10 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 	}
 	r.lastState = tunnelState
 	minikubeState := tunnelState.MinikubeState.String()
+	route := tunnelState.TunnelID.Route.String()
+	if tunnelState.TunnelID.Route == nil {
+		route = "none, forwarding ports in userspace"
+	}
 
 	managedServices := fmt.Sprintf("[%s]", strings.Join(tunnelState.PatchedServices, ", "))
 
@@ -76,7 +81,7 @@ func (r *simpleReporter) Report(tunnelState *Status) {
 	services: %s
 %s`, tunnelState.TunnelID.MachineName,
 		tunnelState.TunnelID.Pid,
-		tunnelState.TunnelID.Route,
+		route,
 		minikubeState,
 		managedServices,
 		errors)))
@@ -90,3 +95,51 @@ func newReporter(out io.Writer) reporter {
 		out: out,
 	}
 }
+
+//jsonReporter reports every status as a line of JSON, for tools watching the health of the tunnel
+type jsonReporter struct {
+	out io.Writer
+}
+
+//jsonStatus is the JSON representation of a Status
+type jsonStatus struct {
+	MachineName               string   `json:"machine"`
+	Pid                       int      `json:"pid"`
+	Route                     string   `json:"route,omitempty"`
+	MinikubeState             string   `json:"minikube"`
+	PatchedServices           []string `json:"services"`
+	MinikubeError             string   `json:"minikubeError,omitempty"`
+	RouteError                string   `json:"routeError,omitempty"`
+	LoadBalancerEmulatorError string   `json:"loadBalancerEmulatorError,omitempty"`
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func (r *jsonReporter) Report(tunnelState *Status) {
+	s := jsonStatus{
+		MachineName:               tunnelState.TunnelID.MachineName,
+		Pid:                       tunnelState.TunnelID.Pid,
+		MinikubeState:             tunnelState.MinikubeState.String(),
+		PatchedServices:           tunnelState.PatchedServices,
+		MinikubeError:             errorString(tunnelState.MinikubeError),
+		RouteError:                errorString(tunnelState.RouteError),
+		LoadBalancerEmulatorError: errorString(tunnelState.LoadBalancerEmulatorError),
+	}
+	if tunnelState.TunnelID.Route != nil {
+		s.Route = tunnelState.TunnelID.Route.String()
+	}
+	if s.PatchedServices == nil {
+		s.PatchedServices = []string{}
+	}
+	enc := json.NewEncoder(r.out)
+	//routes contain "->", which is easier to read unescaped
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		glog.Errorf("failed to report state %s", err)
+	}
+}
@@ -129,3 +129,33 @@ func (w *recordingWriter) Write(p []byte) (n int, err error) {
 	w.output = fmt.Sprintf("%s%s", w.output, p)
 	return 0, nil
 }
+
+func TestJSONReporter(t *testing.T) {
+	out := &recordingWriter{}
+	reporter := &jsonReporter{out: out}
+	reporter.Report(&Status{
+		TunnelID: ID{
+			Route:       unsafeParseRoute("1.2.3.4", "10.96.0.0/12"),
+			MachineName: "testmachine",
+			Pid:         1234,
+		},
+		MinikubeState:   Running,
+		PatchedServices: []string{"svc1", "svc2"},
+	})
+	reporter.Report(&Status{
+		TunnelID: ID{
+			MachineName: "testmachine",
+			Pid:         1234,
+		},
+		MinikubeState: Unknown,
+		MinikubeError: errors.New("minikubeerror"),
+		RouteError:    errors.New("route error"),
+	})
+
+	expectedOutput := `{"machine":"testmachine","pid":1234,"route":"10.96.0.0/12 -> 1.2.3.4","minikube":"Running","services":["svc1","svc2"]}
+{"machine":"testmachine","pid":1234,"minikube":"Unknown","services":[],"minikubeError":"minikubeerror","routeError":"route error"}
+`
+	if out.output != expectedOutput {
+		t.Errorf("Expected: %q\nGot:      %q", expectedOutput, out.output)
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/host"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	core_v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/sshutil"
+)
+
+//reverseTunnelLabel marks the services created by reverse tunnels, which are the only ones they delete
+const reverseTunnelLabel = "kubernetes.io/minikube-reverse-tunnel"
+
+//relayStartupTime is how long to wait for the node listener to fail, e.g. because its port is in use
+const relayStartupTime = 500 * time.Millisecond
+
+//reverseTunnel exposes a host address as a selectorless Service in the cluster. A listener on the node forwards
+//connections back to the host, through a remote forward of an SSH connection to the node.
+type reverseTunnel struct {
+	hostAddr    string
+	namespace   string
+	name        string
+	servicePort int32
+	nodePort    int32
+
+	//collaborators
+	clusterInspector *clusterInspector
+	coreV1Client     v1.CoreV1Interface
+	reporter         reporter
+
+	relay  *relay
+	status *Status
+}
+
+//relay is the listener on the node, which forwards connections to the host
+type relay struct {
+	nodeIP   string
+	client   *ssh.Client
+	listener net.Listener
+	session  *ssh.Session
+	output   *bytes.Buffer
+	done     chan error
+}
+
+//parseServiceName parses a service given as namespace/name[:port]
+func parseServiceName(s string) (namespace string, name string, port int32, err error) {
+	if i := strings.LastIndex(s, ":"); i != -1 {
+		p, err := strconv.ParseUint(s[i+1:], 10, 16)
+		if err != nil || p == 0 {
+			return "", "", 0, fmt.Errorf("invalid port in service %q", s)
+		}
+		port = int32(p)
+		s = s[:i]
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", 0, fmt.Errorf("service %q should be namespace/name[:port]", s)
+	}
+	return parts[0], parts[1], port, nil
+}
+
+func newReverseTunnel(machineName string,
+	machineAPI libmachine.API,
+	configLoader config.Loader,
+	v1Core v1.CoreV1Interface, hostAddr string, service string) (*reverseTunnel, error) {
+	_, portStr, err := net.SplitHostPort(hostAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host address %q: %s", hostAddr, err)
+	}
+	hostPort, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || hostPort == 0 {
+		return nil, fmt.Errorf("invalid port in host address %q", hostAddr)
+	}
+	namespace, name, servicePort, err := parseServiceName(service)
+	if err != nil {
+		return nil, err
+	}
+	if servicePort == 0 {
+		servicePort = int32(hostPort)
+	}
+
+	ci := &clusterInspector{
+		machineName:  machineName,
+		machineAPI:   machineAPI,
+		configLoader: configLoader,
+	}
+	state, _, err := ci.getStateAndHost()
+	machineAPI.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine cluster info: %s", err)
+	}
+
+	return &reverseTunnel{
+		hostAddr:         hostAddr,
+		namespace:        namespace,
+		name:             name,
+		servicePort:      servicePort,
+		nodePort:         int32(hostPort),
+		clusterInspector: ci,
+		coreV1Client:     v1Core,
+		reporter: &simpleReporter{
+			out: os.Stdout,
+		},
+		status: &Status{
+			TunnelID: ID{
+				MachineName: machineName,
+				Pid:         getPid(),
+			},
+			MinikubeState: state,
+		},
+	}, nil
+}
+
+func (t *reverseTunnel) update() *Status {
+	glog.V(3).Info("updating tunnel status...")
+	state, h, err := t.clusterInspector.getStateAndHost()
+	t.status.MinikubeState, t.status.MinikubeError = state, err
+	defer t.clusterInspector.machineAPI.Close()
+	if t.status.MinikubeState == Running {
+		if t.relay != nil {
+			select {
+			case err := <-t.relay.done:
+				t.status.RouteError = fmt.Errorf("node listener exited: %v: %s", err, strings.TrimSpace(t.relay.output.String()))
+				t.relay.close(t.nodePort)
+				t.relay = nil
+			default:
+			}
+		}
+		if t.relay == nil {
+			t.relay, t.status.RouteError = startRelay(h, t.nodePort, t.hostAddr)
+		}
+		if t.relay != nil {
+			t.status.LoadBalancerEmulatorError = ensureReverseService(t.coreV1Client, t.namespace, t.name, t.servicePort, t.relay.nodeIP, t.nodePort)
+			t.status.PatchedServices = nil
+			if t.status.LoadBalancerEmulatorError == nil {
+				t.status.PatchedServices = []string{t.namespace + "/" + t.name}
+			}
+		}
+	}
+	glog.V(3).Infof("sending report %s", t.status)
+	t.reporter.Report(t.status.Clone())
+	return t.status
+}
+
+func (t *reverseTunnel) cleanup() *Status {
+	if t.status.MinikubeState == Running {
+		t.status.LoadBalancerEmulatorError = removeReverseService(t.coreV1Client, t.namespace, t.name)
+	}
+	if t.relay != nil {
+		t.relay.close(t.nodePort)
+		t.relay = nil
+	}
+	return t.status
+}
+
+//startRelay starts a listener on the node, which forwards connections to the host address
+func startRelay(h *host.Host, nodePort int32, hostAddr string) (*relay, error) {
+	nodeIP, err := h.Driver.GetIP()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting node IP")
+	}
+	client, err := sshutil.NewSSHClient(h.Driver)
+	if err != nil {
+		return nil, errors.Wrap(err, "ssh client")
+	}
+	//sshd only binds remote forwards to the loopback address, so socat exposes them on the node IP
+	l, err := client.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		client.Close()
+		return nil, errors.Wrap(err, "remote forward")
+	}
+	r := &relay{
+		nodeIP:   nodeIP,
+		client:   client,
+		listener: l,
+		output:   &bytes.Buffer{},
+		done:     make(chan error, 1),
+	}
+	go r.serve(hostAddr)
+
+	r.session, err = client.NewSession()
+	if err != nil {
+		r.close(nodePort)
+		return nil, errors.Wrap(err, "ssh session")
+	}
+	//with a terminal, socat is hung up when the session is closed
+	if err := r.session.RequestPty("xterm", 40, 80, ssh.TerminalModes{}); err != nil {
+		r.close(nodePort)
+		return nil, errors.Wrap(err, "requesting pty")
+	}
+	r.session.Stdout = r.output
+	cmd := fmt.Sprintf("socat TCP-LISTEN:%d,bind=%s,fork,reuseaddr TCP:127.0.0.1:%d", nodePort, nodeIP, l.Addr().(*net.TCPAddr).Port)
+	glog.Infof("Starting node listener: %s", cmd)
+	if err := r.session.Start(cmd); err != nil {
+		r.close(nodePort)
+		return nil, errors.Wrap(err, "starting socat")
+	}
+	go func() {
+		r.done <- r.session.Wait()
+	}()
+
+	select {
+	case err := <-r.done:
+		out := strings.TrimSpace(r.output.String())
+		r.close(nodePort)
+		return nil, fmt.Errorf("node listener exited: %v: %s", err, out)
+	case <-time.After(relayStartupTime):
+	}
+	glog.Infof("Forwarding %s:%d to %s", nodeIP, nodePort, hostAddr)
+	return r, nil
+}
+
+func (r *relay) serve(hostAddr string) {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			glog.V(3).Infof("stopped accepting connections for %s: %v", hostAddr, err)
+			return
+		}
+		go func() {
+			defer conn.Close()
+			local, err := net.Dial("tcp", hostAddr)
+			if err != nil {
+				glog.Errorf("error connecting to %s: %v", hostAddr, err)
+				return
+			}
+			defer local.Close()
+			pipe(conn, local)
+		}()
+	}
+}
+
+func (r *relay) close(nodePort int32) {
+	if r.session != nil {
+		r.session.Close()
+		//in case the hangup did not reach socat
+		if s, err := r.client.NewSession(); err == nil {
+			s.Run(fmt.Sprintf("pkill -f 'socat TCP-LISTEN:%d,'", nodePort))
+			s.Close()
+		}
+	}
+	r.listener.Close()
+	r.client.Close()
+}
+
+//ensureReverseService creates or updates a selectorless service, with endpoints pointing at the node listener
+func ensureReverseService(v1Core v1.CoreV1Interface, namespace, name string, servicePort int32, nodeIP string, nodePort int32) error {
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{reverseTunnelLabel: "true"},
+	}
+	svc, err := v1Core.Services(namespace).Get(name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		glog.Infof("Creating service %s/%s", namespace, name)
+		svc = &core_v1.Service{
+			ObjectMeta: meta,
+			Spec: core_v1.ServiceSpec{
+				Ports: []core_v1.ServicePort{{
+					Protocol:   core_v1.ProtocolTCP,
+					Port:       servicePort,
+					TargetPort: intstr.FromInt(int(nodePort)),
+				}},
+			},
+		}
+		if _, err := v1Core.Services(namespace).Create(svc); err != nil {
+			return errors.Wrap(err, "creating service")
+		}
+	case err != nil:
+		return errors.Wrap(err, "getting service")
+	case svc.Labels[reverseTunnelLabel] != "true":
+		return fmt.Errorf("service %s/%s already exists, and was not created by a reverse tunnel", namespace, name)
+	}
+
+	subsets := []core_v1.EndpointSubset{{
+		Addresses: []core_v1.EndpointAddress{{IP: nodeIP}},
+		Ports:     []core_v1.EndpointPort{{Protocol: core_v1.ProtocolTCP, Port: nodePort}},
+	}}
+	ep, err := v1Core.Endpoints(namespace).Get(name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := v1Core.Endpoints(namespace).Create(&core_v1.Endpoints{ObjectMeta: meta, Subsets: subsets}); err != nil {
+			return errors.Wrap(err, "creating endpoints")
+		}
+	case err != nil:
+		return errors.Wrap(err, "getting endpoints")
+	case !reflect.DeepEqual(ep.Subsets, subsets):
+		ep.Subsets = subsets
+		if _, err := v1Core.Endpoints(namespace).Update(ep); err != nil {
+			return errors.Wrap(err, "updating endpoints")
+		}
+	}
+	return nil
+}
+
+//removeReverseService deletes the service and endpoints, if they were created by a reverse tunnel
+func removeReverseService(v1Core v1.CoreV1Interface, namespace, name string) error {
+	svc, err := v1Core.Services(namespace).Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "getting service")
+	}
+	if svc.Labels[reverseTunnelLabel] != "true" {
+		glog.Infof("Not deleting service %s/%s, as it was not created by a reverse tunnel", namespace, name)
+		return nil
+	}
+	glog.Infof("Deleting service %s/%s", namespace, name)
+	if err := v1Core.Endpoints(namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "deleting endpoints")
+	}
+	if err := v1Core.Services(namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "deleting service")
+	}
+	return nil
+}
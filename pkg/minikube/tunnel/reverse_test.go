@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"testing"
+
+	apiV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTrackingCoreClient(objects ...apiV1.Service) *fake.FakeCoreV1 {
+	tracker := k8stesting.NewObjectTracker(scheme.Scheme, scheme.Codecs.UniversalDecoder())
+	for i := range objects {
+		tracker.Add(&objects[i])
+	}
+	f := &k8stesting.Fake{}
+	f.AddReactor("*", "*", k8stesting.ObjectReaction(tracker))
+	return &fake.FakeCoreV1{Fake: f}
+}
+
+func TestParseServiceName(t *testing.T) {
+	tcs := []struct {
+		in        string
+		namespace string
+		name      string
+		port      int32
+		err       bool
+	}{
+		{in: "default/webhook", namespace: "default", name: "webhook"},
+		{in: "kube-system/webhook:443", namespace: "kube-system", name: "webhook", port: 443},
+		{in: "webhook", err: true},
+		{in: "default/webhook:https", err: true},
+		{in: "/webhook", err: true},
+		{in: "a/b/c", err: true},
+	}
+	for _, tc := range tcs {
+		namespace, name, port, err := parseServiceName(tc.in)
+		if (err != nil) != tc.err {
+			t.Errorf("parseServiceName(%q) error = %v, expected error: %t", tc.in, err, tc.err)
+			continue
+		}
+		if namespace != tc.namespace || name != tc.name || port != tc.port {
+			t.Errorf("parseServiceName(%q) = %s, %s, %d, expected %s, %s, %d", tc.in, namespace, name, port, tc.namespace, tc.name, tc.port)
+		}
+	}
+}
+
+func TestReverseService(t *testing.T) {
+	client := newTrackingCoreClient()
+
+	if err := ensureReverseService(client, "default", "webhook", 443, "192.168.99.100", 8443); err != nil {
+		t.Fatalf("ensureReverseService: %v", err)
+	}
+	svc, err := client.Services("default").Get("webhook", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get service: %v", err)
+	}
+	if len(svc.Spec.Selector) != 0 || svc.Spec.Ports[0].Port != 443 || svc.Spec.Ports[0].TargetPort.IntValue() != 8443 {
+		t.Errorf("unexpected service spec: %+v", svc.Spec)
+	}
+	ep, err := client.Endpoints("default").Get("webhook", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get endpoints: %v", err)
+	}
+	if ep.Subsets[0].Addresses[0].IP != "192.168.99.100" || ep.Subsets[0].Ports[0].Port != 8443 {
+		t.Errorf("unexpected endpoints: %+v", ep.Subsets)
+	}
+
+	//the endpoints follow the node IP, e.g. after a restart
+	if err := ensureReverseService(client, "default", "webhook", 443, "192.168.99.101", 8443); err != nil {
+		t.Fatalf("ensureReverseService: %v", err)
+	}
+	ep, err = client.Endpoints("default").Get("webhook", metaV1.GetOptions{})
+	if err != nil || ep.Subsets[0].Addresses[0].IP != "192.168.99.101" {
+		t.Errorf("expected endpoints to be updated, got: %+v, %v", ep, err)
+	}
+
+	if err := removeReverseService(client, "default", "webhook"); err != nil {
+		t.Fatalf("removeReverseService: %v", err)
+	}
+	if _, err := client.Services("default").Get("webhook", metaV1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected service to be deleted, got: %v", err)
+	}
+	if _, err := client.Endpoints("default").Get("webhook", metaV1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected endpoints to be deleted, got: %v", err)
+	}
+}
+
+func TestReverseServiceNotManaged(t *testing.T) {
+	client := newTrackingCoreClient(apiV1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "webhook", Namespace: "default"},
+	})
+
+	if err := ensureReverseService(client, "default", "webhook", 443, "192.168.99.100", 8443); err == nil {
+		t.Errorf("expected an error for an existing service")
+	}
+	if err := removeReverseService(client, "default", "webhook"); err != nil {
+		t.Fatalf("removeReverseService: %v", err)
+	}
+	if _, err := client.Services("default").Get("webhook", metaV1.GetOptions{}); err != nil {
+		t.Errorf("expected the existing service to be kept, got: %v", err)
+	}
+}
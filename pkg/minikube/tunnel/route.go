@@ -76,6 +76,10 @@ func (t *routingTable) Check(route *Route) (exists bool, conflict string, overla
 	exists = false
 	overlaps = []string{}
 	for _, tableLine := range *t {
+		//IPv4 and IPv6 routes are in separate tables, and can never conflict
+		if route.isIPv6() != tableLine.route.isIPv6() {
+			continue
+		}
 		if route.Equal(tableLine.route) {
 			exists = true
 		} else if route.DestCIDR.String() == tableLine.route.DestCIDR.String() &&
@@ -39,7 +39,7 @@ func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
 	gatewayIP := route.Gateway.String()
 
 	glog.Infof("Adding route for CIDR %s to gateway %s", serviceCIDR, gatewayIP)
-	command := exec.Command("sudo", "route", "-n", "add", serviceCIDR, gatewayIP)
+	command := exec.Command("sudo", routeCommand(route, "add", serviceCIDR, gatewayIP)...)
 	glog.Infof("About to run command: %s", command.Args)
 	stdInAndOut, err := command.CombinedOutput()
 	message := fmt.Sprintf("%s", stdInAndOut)
@@ -53,7 +53,11 @@ func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
 }
 
 func (router *osRouter) Inspect(route *Route) (exists bool, conflict string, overlaps []string, err error) {
-	cmd := exec.Command("netstat", "-nr", "-f", "inet")
+	family := "inet"
+	if route.isIPv6() {
+		family = "inet6"
+	}
+	cmd := exec.Command("netstat", "-nr", "-f", family)
 	cmd.Env = append(cmd.Env, "LC_ALL=C")
 	stdInAndOut, err := cmd.CombinedOutput()
 	if err != nil {
@@ -86,8 +90,8 @@ func (router *osRouter) parseTable(table []byte) routingTable {
 		if len(fields) <= 2 {
 			continue
 		}
-		dstCIDRString := router.padCIDR(fields[0])
-		gatewayIPString := fields[1]
+		dstCIDRString := router.padCIDR(stripZone(fields[0]))
+		gatewayIPString := stripZone(fields[1])
 		gatewayIP := net.ParseIP(gatewayIPString)
 
 		_, ipNet, err := net.ParseCIDR(dstCIDRString)
@@ -110,7 +114,34 @@ func (router *osRouter) parseTable(table []byte) routingTable {
 	return t
 }
 
+//routeCommand returns the arguments of a route command for the family of the route
+func routeCommand(route *Route, args ...string) []string {
+	if route.isIPv6() {
+		return append([]string{"route", "-n", args[0], "-inet6"}, args[1:]...)
+	}
+	return append([]string{"route", "-n"}, args...)
+}
+
+//stripZone removes the zone of a link-local IPv6 address, e.g. "fe80::%lo0/64" becomes "fe80::/64"
+func stripZone(addr string) string {
+	i := strings.Index(addr, "%")
+	if i == -1 {
+		return addr
+	}
+	if j := strings.Index(addr[i:], "/"); j != -1 {
+		return addr[:i] + addr[i+j:]
+	}
+	return addr[:i]
+}
+
 func (router *osRouter) padCIDR(origCIDR string) string {
+	//IPv6 destinations are not abbreviated by netstat, except for single hosts
+	if strings.Contains(origCIDR, ":") {
+		if !strings.Contains(origCIDR, "/") {
+			return origCIDR + "/128"
+		}
+		return origCIDR
+	}
 	s := ""
 	dots := 0
 	slash := false
@@ -151,7 +182,7 @@ func (router *osRouter) Cleanup(route *Route) error {
 	if !exists {
 		return nil
 	}
-	command := exec.Command("sudo", "route", "-n", "delete", route.DestCIDR.String())
+	command := exec.Command("sudo", routeCommand(route, "delete", route.DestCIDR.String())...)
 	stdInAndOut, err := command.CombinedOutput()
 	if err != nil {
 		return err
@@ -159,6 +190,9 @@ func (router *osRouter) Cleanup(route *Route) error {
 	message := fmt.Sprintf("%s", stdInAndOut)
 	glog.V(4).Infof("%s", message)
 	re := regexp.MustCompile("^delete net ([^:]*)$")
+	if route.isIPv6() {
+		re = regexp.MustCompile(`^delete net ([0-9a-f:]*/[0-9]+)\s*$`)
+	}
 	if !re.MatchString(message) {
 		return fmt.Errorf("error deleting route: %s, %d", message, len(strings.Split(message, "\n")))
 	}
@@ -115,6 +115,8 @@ func TestCIDRPadding(t *testing.T) {
 		{inputCIDR: "192.168.43", paddedCIDR: "192.168.43.0/24"},
 		{inputCIDR: "192.168.43.1/32", paddedCIDR: "192.168.43.1/32"},
 		{inputCIDR: "127.0.0.1", paddedCIDR: "127.0.0.1/32"},
+		{inputCIDR: "fd00::/108", paddedCIDR: "fd00::/108"},
+		{inputCIDR: "::1", paddedCIDR: "::1/128"},
 	}
 
 	for _, test := range testCases {
@@ -155,3 +157,35 @@ Destination        Gateway            Flags        Refs      Use   Netif Expire
 		t.Errorf("expected:\n %s\ngot\n %s", expectedRt.String(), rt.String())
 	}
 }
+
+func TestRoutingTableParserIPv6(t *testing.T) {
+	table := `Routing tables
+
+Internet6:
+Destination                             Gateway                         Flags         Netif Expire
+default                                 fe80::1%en0                     UGc             en0
+::1                                     ::1                             UHL             lo0
+fd00::/108                              fd00:1::2                       UGSc            en0
+fe80::%lo0/64                           fe80::1%lo0                     UcI             lo0
+fe80::%en0/64                           link#4                          UCI             en0
+`
+	rt := (&osRouter{}).parseTable([]byte(table))
+
+	expectedRt := routingTable{
+		routingTableLine{
+			route: unsafeParseRoute("::1", "::1/128"),
+			line:  "::1                                     ::1                             UHL             lo0",
+		},
+		routingTableLine{
+			route: unsafeParseRoute("fd00:1::2", "fd00::/108"),
+			line:  "fd00::/108                              fd00:1::2                       UGSc            en0",
+		},
+		routingTableLine{
+			route: unsafeParseRoute("fe80::1", "fe80::/64"),
+			line:  "fe80::%lo0/64                           fe80::1%lo0                     UcI             lo0",
+		},
+	}
+	if !reflect.DeepEqual(rt, expectedRt) {
+		t.Errorf("expected:\n %s\ngot\n %s", expectedRt.String(), rt.String())
+	}
+}
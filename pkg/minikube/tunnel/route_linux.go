@@ -38,7 +38,7 @@ func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
 	gatewayIP := route.Gateway.String()
 
 	glog.Infof("Adding route for CIDR %s to gateway %s", serviceCIDR, gatewayIP)
-	command := exec.Command("sudo", "ip", "route", "add", serviceCIDR, "via", gatewayIP)
+	command := exec.Command("sudo", ipCommand(route, "route", "add", serviceCIDR, "via", gatewayIP)...)
 	glog.Infof("About to run command: %s", command.Args)
 	stdInAndOut, err := command.CombinedOutput()
 	message := string(stdInAndOut)
@@ -54,7 +54,8 @@ func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
 }
 
 func (router *osRouter) Inspect(route *Route) (exists bool, conflict string, overlaps []string, err error) {
-	cmd := exec.Command("ip", "r")
+	args := ipCommand(route, "r")
+	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Env = append(cmd.Env, "LC_ALL=C")
 	stdInAndOut, err := cmd.CombinedOutput()
 	if err != nil {
@@ -76,21 +77,25 @@ func (router *osRouter) parseTable(table []byte) routingTable {
 
 		//don't care about the routes that 0.0.0.0
 		if len(fields) == 0 ||
-			len(fields) > 0 && (fields[0] == "default" || fields[0] == "0.0.0.0") {
+			len(fields) > 0 && (fields[0] == "default" || fields[0] == "0.0.0.0" || fields[0] == "::") {
 			continue
 		}
 
 		if len(fields) > 2 {
 
 			//assuming "10.96.0.0/12 via 192.168.39.47 dev virbr1"
-			dstCIDRString := fields[0]
+			dstCIDRString := hostCIDR(fields[0])
 			gatewayIPString := fields[2]
 			gatewayIP := net.ParseIP(gatewayIPString)
 
-			//if not via format, then gateway is assumed to be 0.0.0.0
+			//if not via format, then gateway is assumed to be 0.0.0.0, or :: for IPv6
 			// "1.2.3.0/24 dev eno1 proto kernel scope link src 1.2.3.54 metric 100"
+			// "fe80::/64 dev eno1 proto kernel metric 256 pref medium"
 			if fields[1] != "via" {
 				gatewayIP = net.ParseIP("0.0.0.0")
+				if strings.Contains(dstCIDRString, ":") {
+					gatewayIP = net.ParseIP("::")
+				}
 			}
 
 			_, ipNet, err := net.ParseCIDR(dstCIDRString)
@@ -115,6 +120,26 @@ func (router *osRouter) parseTable(table []byte) routingTable {
 	return t
 }
 
+//ipCommand returns the arguments of an ip command for the family of the route
+func ipCommand(route *Route, args ...string) []string {
+	if route.isIPv6() {
+		return append([]string{"ip", "-6"}, args...)
+	}
+	return append([]string{"ip"}, args...)
+}
+
+//hostCIDR returns the CIDR of a destination, which is a single host if it has no prefix length
+// "::1 dev lo proto kernel metric 256 pref medium"
+func hostCIDR(dst string) string {
+	if strings.Contains(dst, "/") {
+		return dst
+	}
+	if strings.Contains(dst, ":") {
+		return dst + "/128"
+	}
+	return dst + "/32"
+}
+
 func (router *osRouter) Cleanup(route *Route) error {
 	exists, err := isValidToAddOrDelete(router, route)
 	if err != nil {
@@ -127,7 +152,7 @@ func (router *osRouter) Cleanup(route *Route) error {
 	gatewayIP := route.Gateway.String()
 
 	glog.Infof("Cleaning up route for CIDR %s to gateway %s\n", serviceCIDR, gatewayIP)
-	command := exec.Command("sudo", "ip", "route", "delete", serviceCIDR)
+	command := exec.Command("sudo", ipCommand(route, "route", "delete", serviceCIDR)...)
 	stdInAndOut, err := command.CombinedOutput()
 	message := fmt.Sprintf("%s", stdInAndOut)
 	glog.Infof("%s", message)
@@ -21,6 +21,7 @@ package tunnel
 import (
 	"net"
 	"os/exec"
+	"reflect"
 	"testing"
 )
 
@@ -121,6 +122,45 @@ func TestParseTable(t *testing.T) {
 	}
 }
 
+func TestParseTableIPv6(t *testing.T) {
+
+	const table = `::1 dev lo proto kernel metric 256 pref medium
+fd00::/108 via fd00:1::2 dev virbr1 metric 1024 pref medium
+fe80::/64 dev eno1 proto kernel metric 256 pref medium
+default via fe80::1 dev eno1 proto ra metric 100 pref medium`
+
+	rt := (&osRouter{}).parseTable([]byte(table))
+
+	expectedRt := routingTable{
+		routingTableLine{
+			route: unsafeParseRoute("::", "::1/128"),
+			line:  "::1 dev lo proto kernel metric 256 pref medium",
+		},
+		routingTableLine{
+			route: unsafeParseRoute("fd00:1::2", "fd00::/108"),
+			line:  "fd00::/108 via fd00:1::2 dev virbr1 metric 1024 pref medium",
+		},
+		routingTableLine{
+			route: unsafeParseRoute("::", "fe80::/64"),
+			line:  "fe80::/64 dev eno1 proto kernel metric 256 pref medium",
+		},
+	}
+	if !expectedRt.Equal(&rt) {
+		t.Errorf("expected:\n %s\ngot\n %s", expectedRt.String(), rt.String())
+	}
+}
+
+func TestIPCommand(t *testing.T) {
+	v4 := ipCommand(unsafeParseRoute("192.168.39.47", "10.96.0.0/12"), "r")
+	if !reflect.DeepEqual(v4, []string{"ip", "r"}) {
+		t.Errorf("expected [ip r], got %v", v4)
+	}
+	v6 := ipCommand(unsafeParseRoute("fd00:1::2", "fd00::/108"), "r")
+	if !reflect.DeepEqual(v6, []string{"ip", "-6", "r"}) {
+		t.Errorf("expected [ip -6 r], got %v", v6)
+	}
+}
+
 func addRoute(t *testing.T, cidr string, gw string) {
 	command := exec.Command("sudo", "ip", "route", "add", cidr, "via", gw)
 	sout, err := command.CombinedOutput()
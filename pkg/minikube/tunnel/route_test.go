@@ -106,6 +106,50 @@ func TestRoutingTable(t *testing.T) {
 				"overlap line2",
 			},
 		},
+
+		{
+			name: "IPv6, has overlap and a conflict",
+			table: routingTable{
+				{
+					route: unsafeParseRoute("fd00:1::1", "fd00::/108"),
+					line:  "conflicting line",
+				},
+				{
+					route: unsafeParseRoute("::", "fd00::/64"),
+					line:  "overlap line1",
+				},
+				{
+					route: unsafeParseRoute("::", "fe80::/64"),
+					line:  "no overlap",
+				},
+			},
+			route: unsafeParseRoute("fd00:1::2", "fd00::/108"),
+
+			exists:   false,
+			conflict: "conflicting line",
+			overlaps: []string{
+				"overlap line1",
+			},
+		},
+
+		{
+			name: "IPv6 exists, IPv4 routes are ignored",
+			table: routingTable{
+				{
+					route: unsafeParseRoute("192.168.1.1", "0.0.0.0/0"),
+					line:  "ipv4 line",
+				},
+				{
+					route: unsafeParseRoute("fd00:1::2", "fd00::/108"),
+					line:  "same",
+				},
+			},
+			route: unsafeParseRoute("fd00:1::2", "fd00::/108"),
+
+			exists:   true,
+			conflict: "",
+			overlaps: []string{},
+		},
 	}
 
 	for _, tc := range tcs {
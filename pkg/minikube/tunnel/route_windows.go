@@ -26,6 +26,10 @@ import (
 )
 
 func (router *osRouter) EnsureRouteIsAdded(route *Route) error {
+	//"route ADD" only takes IPv4 masks
+	if route.isIPv6() {
+		return fmt.Errorf("IPv6 routes are not supported on Windows: %s", route.DestCIDR)
+	}
 	exists, err := isValidToAddOrDelete(router, route)
 	if err != nil {
 		return err
@@ -18,6 +18,7 @@ package tunnel
 
 import (
 	"fmt"
+	"net"
 	"os"
 
 	"os/exec"
@@ -27,6 +28,7 @@ import (
 	"github.com/docker/machine/libmachine/host"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/minikube/pkg/minikube/config"
 )
@@ -93,26 +95,68 @@ type tunnel struct {
 	loadBalancerEmulator loadBalancerEmulator
 	reporter             reporter
 	registry             *persistentRegistry
+	ipPool               *ipPool
 
 	status *Status
+	//poolID is the registry ID of the route to the LoadBalancer IP pool, if any
+	poolID *ID
+}
+
+//setIPPool makes the tunnel allocate LoadBalancer IPs from a pool instead of using the ClusterIP of services,
+//and route the pool to the cluster
+func (t *tunnel) setIPPool(pool *net.IPNet, store *persistentAllocations) error {
+	route := t.status.TunnelID.Route
+	if overlaps(pool, route.DestCIDR) {
+		return fmt.Errorf("the LoadBalancer IP pool %s overlaps the service CIDR %s", pool, route.DestCIDR)
+	}
+	id := &ID{
+		Route: &Route{
+			Gateway:  route.Gateway,
+			DestCIDR: pool,
+		},
+		MachineName: t.status.TunnelID.MachineName,
+		Pid:         t.status.TunnelID.Pid,
+	}
+	runningTunnel, err := t.registry.IsAlreadyDefinedAndRunning(id)
+	if err != nil {
+		return fmt.Errorf("unable to check tunnel registry for conflict: %s", err)
+	}
+	if runningTunnel != nil {
+		return fmt.Errorf("another tunnel is already running, shut it down first: %s", runningTunnel)
+	}
+	t.poolID = id
+	t.ipPool = &ipPool{
+		cidr:        pool,
+		machineName: t.status.TunnelID.MachineName,
+		store:       store,
+	}
+	t.loadBalancerEmulator.ingressIP = t.ipPool.ingressIP
+	return nil
 }
 
 func (t *tunnel) cleanup() *Status {
-	glog.V(3).Infof("cleaning up %s", t.status.TunnelID.Route)
-	err := t.router.Cleanup(t.status.TunnelID.Route)
+	cleanupRoute(t, &t.status.TunnelID)
+	if t.poolID != nil {
+		cleanupRoute(t, t.poolID)
+	}
+	if t.status.MinikubeState == Running {
+		t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.loadBalancerEmulator.Cleanup()
+	}
+	return t.status
+}
+
+func cleanupRoute(t *tunnel, id *ID) {
+	glog.V(3).Infof("cleaning up %s", id.Route)
+	err := t.router.Cleanup(id.Route)
 	if err != nil {
 		t.status.RouteError = errors.Errorf("error cleaning up route: %v", err)
 		glog.V(3).Infof(t.status.RouteError.Error())
 	} else {
-		err = t.registry.Remove(t.status.TunnelID.Route)
+		err = t.registry.Remove(id.Route)
 		if err != nil {
 			glog.V(3).Infof("error removing route from registry: %v", err)
 		}
 	}
-	if t.status.MinikubeState == Running {
-		t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.loadBalancerEmulator.Cleanup()
-	}
-	return t.status
 }
 
 func (t *tunnel) update() *Status {
@@ -122,9 +166,23 @@ func (t *tunnel) update() *Status {
 	defer t.clusterInspector.machineAPI.Close()
 	if t.status.MinikubeState == Running {
 		glog.V(3).Infof("minikube is running, trying to add route%s", t.status.TunnelID.Route)
-		setupRoute(t, h)
+		setupRoute(t, h, &t.status.TunnelID)
+		if t.status.RouteError == nil && t.poolID != nil {
+			setupRoute(t, h, t.poolID)
+		}
 		if t.status.RouteError == nil {
+			var poolErr error
+			if t.ipPool != nil {
+				poolErr = t.syncIPPool()
+			}
 			t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.loadBalancerEmulator.PatchServices()
+			if t.status.LoadBalancerEmulatorError == nil {
+				t.status.LoadBalancerEmulatorError = poolErr
+			}
+			recordPatchedServices(t, &t.status.TunnelID)
+			if t.poolID != nil {
+				recordPatchedServices(t, t.poolID)
+			}
 		}
 	}
 	glog.V(3).Infof("sending report %s", t.status)
@@ -132,21 +190,36 @@ func (t *tunnel) update() *Status {
 	return t.status
 }
 
-func setupRoute(t *tunnel, h *host.Host) {
-	exists, conflict, _, err := t.router.Inspect(t.status.TunnelID.Route)
+//recordPatchedServices records the patched services in the registry, for "minikube tunnel list"
+func recordPatchedServices(t *tunnel, id *ID) {
+	if err := t.registry.SetPatchedServices(id, t.status.PatchedServices); err != nil {
+		glog.Errorf("failed to record patched services: %s", err)
+	}
+}
+
+func (t *tunnel) syncIPPool() error {
+	serviceList, err := t.loadBalancerEmulator.coreV1Client.Services("").List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "listing services")
+	}
+	return t.ipPool.sync(serviceList.Items)
+}
+
+func setupRoute(t *tunnel, h *host.Host, id *ID) {
+	exists, conflict, _, err := t.router.Inspect(id.Route)
 	if err != nil {
 		t.status.RouteError = fmt.Errorf("error checking for route state: %s", err)
 		return
 	}
 
 	if !exists && len(conflict) == 0 {
-		t.status.RouteError = t.router.EnsureRouteIsAdded(t.status.TunnelID.Route)
+		t.status.RouteError = t.router.EnsureRouteIsAdded(id.Route)
 		if t.status.RouteError != nil {
 			return
 		}
 		//the route was added successfully, we need to make sure the registry has it too
 		//this might fail in race conditions, when another process created this tunnel
-		if err := t.registry.Register(&t.status.TunnelID); err != nil {
+		if err := t.registry.Register(id); err != nil {
 			glog.Errorf("failed to register tunnel: %s", err)
 			t.status.RouteError = err
 			return
@@ -169,7 +242,7 @@ func setupRoute(t *tunnel, h *host.Host) {
 	}
 
 	//the route exists, make sure that this process owns it in the registry
-	existingTunnel, err := t.registry.IsAlreadyDefinedAndRunning(&t.status.TunnelID)
+	existingTunnel, err := t.registry.IsAlreadyDefinedAndRunning(id)
 	if err != nil {
 		glog.Errorf("failed to check for other tunnels: %s", err)
 		t.status.RouteError = err
@@ -178,7 +251,7 @@ func setupRoute(t *tunnel, h *host.Host) {
 
 	if existingTunnel == nil {
 		//the route exists, but "orphaned", this process will "own it" in the registry
-		if err := t.registry.Register(&t.status.TunnelID); err != nil {
+		if err := t.registry.Register(id); err != nil {
 			glog.Errorf("failed to register tunnel: %s", err)
 			t.status.RouteError = err
 		}
@@ -21,6 +21,8 @@ import (
 
 	"context"
 	"fmt"
+	"io"
+	"net"
 
 	"github.com/docker/machine/libmachine"
 	"github.com/golang/glog"
@@ -33,9 +35,21 @@ import (
 // It keeps track of created tunnels for multiple vms so that it can cleanup
 // after unclean shutdowns.
 type Manager struct {
-	delay    time.Duration
-	registry *persistentRegistry
-	router   router
+	delay       time.Duration
+	registry    *persistentRegistry
+	allocations *persistentAllocations
+	router      router
+	//reporter overrides the reporter of started tunnels, if set
+	reporter reporter
+}
+
+// Info describes a tunnel in the registry, with the same JSON field names as the status reports
+type Info struct {
+	Route           string   `json:"route,omitempty"`
+	MachineName     string   `json:"machine"`
+	Pid             int      `json:"pid"`
+	Running         bool     `json:"running"`
+	PatchedServices []string `json:"services"`
 }
 
 //stateCheckInterval defines how frequently the cluster and route states are checked
@@ -48,19 +62,85 @@ func NewManager() *Manager {
 		registry: &persistentRegistry{
 			path: constants.TunnelRegistryPath(),
 		},
+		allocations: &persistentAllocations{
+			path: constants.LoadBalancerIPsPath(),
+		},
 		router: &osRouter{},
 	}
 }
 
-// StartTunnel starts the tunnel
-func (mgr *Manager) StartTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core v1.CoreV1Interface) (done chan bool, err error) {
+// StartTunnel starts the tunnel. If lbIPPool is not nil, LoadBalancer services get an IP allocated
+// from the pool instead of their ClusterIP, and the pool is routed to the cluster too.
+func (mgr *Manager) StartTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core v1.CoreV1Interface, lbIPPool *net.IPNet) (done chan bool, err error) {
 	tunnel, err := newTunnel(machineName, machineAPI, configLoader, v1Core, mgr.registry, mgr.router)
 	if err != nil {
 		return nil, fmt.Errorf("error creating tunnel: %s", err)
 	}
+	if lbIPPool != nil {
+		if err := tunnel.setIPPool(lbIPPool, mgr.allocations); err != nil {
+			return nil, fmt.Errorf("error setting up LoadBalancer IP pool: %s", err)
+		}
+	}
+	if mgr.reporter != nil {
+		tunnel.reporter = mgr.reporter
+	}
+	return mgr.startTunnel(ctx, tunnel)
+
+}
+
+// StartUserspaceTunnel starts a tunnel that forwards the ports of LoadBalancer services from listeners on the bind address,
+// which unlike a route requires no root privileges
+func (mgr *Manager) StartUserspaceTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core v1.CoreV1Interface, bindAddress string) (done chan bool, err error) {
+	tunnel, err := newUserspaceTunnel(machineName, machineAPI, configLoader, v1Core, bindAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tunnel: %s", err)
+	}
+	if mgr.reporter != nil {
+		tunnel.reporter = mgr.reporter
+	}
+	return mgr.startTunnel(ctx, tunnel)
+}
+
+// StartReverseTunnel starts a tunnel that exposes a host address as a service in the cluster, given as namespace/name[:port]
+func (mgr *Manager) StartReverseTunnel(ctx context.Context, machineName string, machineAPI libmachine.API, configLoader config.Loader, v1Core v1.CoreV1Interface, hostAddr string, service string) (done chan bool, err error) {
+	tunnel, err := newReverseTunnel(machineName, machineAPI, configLoader, v1Core, hostAddr, service)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tunnel: %s", err)
+	}
+	if mgr.reporter != nil {
+		tunnel.reporter = mgr.reporter
+	}
 	return mgr.startTunnel(ctx, tunnel)
+}
 
+// ReportJSON makes started tunnels report their status on each check as a line of JSON
+func (mgr *Manager) ReportJSON(out io.Writer) {
+	mgr.reporter = &jsonReporter{out: out}
+}
+
+// ListTunnels lists the tunnels in the registry, and whether their process is running
+func (mgr *Manager) ListTunnels() ([]Info, error) {
+	tunnels, err := mgr.registry.List()
+	if err != nil {
+		return nil, fmt.Errorf("error listing tunnels from registry: %s", err)
+	}
+	infos := []Info{}
+	for _, t := range tunnels {
+		isRunning, err := checkIfRunning(t.Pid)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if tunnel is running: %s", err)
+		}
+		infos = append(infos, Info{
+			Route:           t.Route.String(),
+			MachineName:     t.MachineName,
+			Pid:             t.Pid,
+			Running:         isRunning,
+			PatchedServices: t.PatchedServices,
+		})
+	}
+	return infos, nil
 }
+
 func (mgr *Manager) startTunnel(ctx context.Context, tunnel controller) (done chan bool, err error) {
 	glog.Info("Setting up tunnel...")
 
@@ -120,20 +200,24 @@ func (mgr *Manager) cleanup(t controller) *Status {
 	return t.cleanup()
 }
 
-// CleanupNotRunningTunnels cleans up tunnels that are not running
+// CleanupNotRunningTunnels cleans up tunnels that are not running,
+// and releases the LoadBalancer IPs of machines without a running tunnel
 func (mgr *Manager) CleanupNotRunningTunnels() error {
 	tunnels, err := mgr.registry.List()
 	if err != nil {
 		return fmt.Errorf("error listing tunnels from registry: %s", err)
 	}
 
+	running := map[string]bool{}
 	for _, tunnel := range tunnels {
 		isRunning, err := checkIfRunning(tunnel.Pid)
 		glog.Infof("%v is running: %t", tunnel, isRunning)
 		if err != nil {
 			return fmt.Errorf("error checking if tunnel is running: %s", err)
 		}
-		if !isRunning {
+		if isRunning {
+			running[tunnel.MachineName] = true
+		} else {
 			err = mgr.router.Cleanup(tunnel.Route)
 			if err != nil {
 				return err
@@ -144,5 +228,16 @@ func (mgr *Manager) CleanupNotRunningTunnels() error {
 			}
 		}
 	}
+
+	if mgr.allocations == nil {
+		return nil
+	}
+	released, err := mgr.allocations.ReleaseExcept(running)
+	if err != nil {
+		return fmt.Errorf("error releasing LoadBalancer IPs: %s", err)
+	}
+	for _, machineName := range released {
+		glog.Infof("Released the LoadBalancer IPs of %s", machineName)
+	}
 	return nil
 }
@@ -20,7 +20,9 @@ import (
 	"testing"
 
 	"context"
+	"encoding/json"
 	"os"
+	"reflect"
 	"time"
 
 	"github.com/golang/glog"
@@ -253,9 +255,19 @@ func TestTunnelManagerCleanup(t *testing.T) {
 		t.Errorf("expected no error got: %v", err)
 	}
 
+	allocations, cleanupAllocations := createTestAllocations(t)
+	defer cleanupAllocations()
+	if err := allocations.save(map[string]map[string]string{
+		"minikube": {"default/svc": "172.30.0.1"},
+		"stopped":  {"default/svc": "172.30.0.2"},
+	}); err != nil {
+		t.Errorf("expected no error got: %v", err)
+	}
+
 	manager := NewManager()
 	manager.router = router
 	manager.registry = reg
+	manager.allocations = allocations
 
 	err = manager.CleanupNotRunningTunnels()
 
@@ -281,6 +293,14 @@ func TestTunnelManagerCleanup(t *testing.T) {
 		t.Errorf("tunnels are not cleaned up properly, expected only running tunnels to stay, got: %v", tunnels)
 	}
 
+	allocs, err := allocations.load()
+	if err != nil {
+		t.Errorf("expected no error got: %v", err)
+	}
+	if _, ok := allocs["stopped"]; ok || len(allocs["minikube"]) != 1 {
+		t.Errorf("expected only the IPs of machines with running tunnels to stay, got: %v", allocs)
+	}
+
 }
 
 type tunnelStub struct {
@@ -299,3 +319,56 @@ func (t *tunnelStub) cleanup() *Status {
 	t.tunnelExists = false
 	return t.mockClusterInfo
 }
+
+func TestTunnelManagerListTunnels(t *testing.T) {
+	reg, cleanup := createTestRegistry(t)
+	defer cleanup()
+
+	running, _, err := registerRunningTunnels(reg)
+	if err != nil {
+		t.Errorf("expected no error got: %v", err)
+	}
+	notRunning, _, err := registerNotRunningTunnels(reg)
+	if err != nil {
+		t.Errorf("expected no error got: %v", err)
+	}
+	if err := reg.SetPatchedServices(running, []string{"svc1"}); err != nil {
+		t.Errorf("expected no error got: %v", err)
+	}
+
+	manager := &Manager{registry: reg}
+	tunnels, err := manager.ListTunnels()
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	if len(tunnels) != 4 {
+		t.Fatalf("expected 4 tunnels, got: %+v", tunnels)
+	}
+	expectedRunning := Info{
+		Route:           running.Route.String(),
+		MachineName:     "minikube",
+		Pid:             running.Pid,
+		Running:         true,
+		PatchedServices: []string{"svc1"},
+	}
+	if !reflect.DeepEqual(tunnels[0], expectedRunning) {
+		t.Errorf("expected %+v, got %+v", expectedRunning, tunnels[0])
+	}
+	//tunnel list -o json uses the field names of the JSON status reports
+	b, err := json.Marshal(tunnels[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, field := range []string{"machine", "pid", "route", "services"} {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("expected field %q in %s", field, b)
+		}
+	}
+	if tunnels[2].Route != notRunning.Route.String() || tunnels[2].Running {
+		t.Errorf("expected %s to not be running, got %+v", notRunning.Route, tunnels[2])
+	}
+}
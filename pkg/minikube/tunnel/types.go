@@ -66,9 +66,17 @@ type Route struct {
 }
 
 func (r *Route) String() string {
+	if r == nil {
+		return "none"
+	}
 	return fmt.Sprintf("%s -> %s", r.DestCIDR.String(), r.Gateway.String())
 }
 
+//isIPv6 returns whether the destination of the route is an IPv6 network
+func (r *Route) isIPv6() bool {
+	return r.DestCIDR.IP.To4() == nil
+}
+
 // Equal checks if two routes are equal
 func (r *Route) Equal(other *Route) bool {
 	return other != nil && r.DestCIDR.IP.Equal(other.DestCIDR.IP) &&
@@ -0,0 +1,364 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/sshutil"
+)
+
+//dialer opens connections to addresses inside the cluster
+type dialer interface {
+	Dial(network, addr string) (net.Conn, error)
+}
+
+//forward is a host listener for a port of a service
+type forward struct {
+	service  string
+	target   string
+	listener net.Listener
+}
+
+//userspaceForwarder opens a host listener for each port of the services of type LoadBalancer,
+//and forwards the accepted connections to the ClusterIP of the service. It needs no root privileges,
+//as long as the service ports are not privileged on the host.
+type userspaceForwarder struct {
+	bindAddress string
+	dialer      dialer
+
+	sync.Mutex
+	forwards map[int32]*forward
+}
+
+func newUserspaceForwarder(bindAddress string, d dialer) *userspaceForwarder {
+	return &userspaceForwarder{
+		bindAddress: bindAddress,
+		dialer:      d,
+		forwards:    map[int32]*forward{},
+	}
+}
+
+//sync opens listeners for the ports of new services, and closes them for the ports of removed services
+func (u *userspaceForwarder) sync(services []core_v1.Service) error {
+	var errs []string
+	desired := map[int32]*forward{}
+	for _, svc := range services {
+		if svc.Spec.Type != core_v1.ServiceTypeLoadBalancer || svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == core_v1.ClusterIPNone {
+			continue
+		}
+		name := svc.Namespace + "/" + svc.Name
+		for _, p := range svc.Spec.Ports {
+			if p.Protocol != "" && p.Protocol != core_v1.ProtocolTCP {
+				glog.V(3).Infof("[%s] skipping port %d, only TCP is forwarded in userspace", name, p.Port)
+				continue
+			}
+			if other, ok := desired[p.Port]; ok {
+				errs = append(errs, fmt.Sprintf("port %d of %s is already forwarded to %s", p.Port, name, other.service))
+				continue
+			}
+			desired[p.Port] = &forward{
+				service: name,
+				target:  net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(int(p.Port))),
+			}
+		}
+	}
+
+	u.Lock()
+	defer u.Unlock()
+	for port, f := range u.forwards {
+		if d, ok := desired[port]; ok && d.target == f.target {
+			continue
+		}
+		glog.Infof("Stopping to forward port %d to %s", port, f.service)
+		f.listener.Close()
+		delete(u.forwards, port)
+	}
+	for port, d := range desired {
+		if _, ok := u.forwards[port]; ok {
+			continue
+		}
+		l, err := net.Listen("tcp", net.JoinHostPort(u.bindAddress, strconv.Itoa(int(port))))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("unable to forward port %d to %s: %v", port, d.service, err))
+			continue
+		}
+		glog.Infof("Forwarding %s to %s (%s)", l.Addr(), d.service, d.target)
+		d.listener = l
+		u.forwards[port] = d
+		go u.serve(d)
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+//isForwarded returns whether all ports of a service are forwarded
+func (u *userspaceForwarder) isForwarded(svc core_v1.Service) bool {
+	u.Lock()
+	defer u.Unlock()
+	for _, p := range svc.Spec.Ports {
+		if p.Protocol != "" && p.Protocol != core_v1.ProtocolTCP {
+			continue
+		}
+		f, ok := u.forwards[p.Port]
+		if !ok || f.service != svc.Namespace+"/"+svc.Name {
+			return false
+		}
+	}
+	return true
+}
+
+func (u *userspaceForwarder) serve(f *forward) {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			glog.V(3).Infof("stopped accepting connections for %s: %v", f.service, err)
+			return
+		}
+		go u.proxy(conn, f)
+	}
+}
+
+func (u *userspaceForwarder) proxy(conn net.Conn, f *forward) {
+	defer conn.Close()
+	remote, err := u.dialer.Dial("tcp", f.target)
+	if err != nil {
+		glog.Errorf("error connecting to %s (%s): %v", f.service, f.target, err)
+		return
+	}
+	defer remote.Close()
+	pipe(conn, remote)
+}
+
+//pipe copies data between two connections until either side is done
+func pipe(a, b net.Conn) {
+	done := make(chan bool, 2)
+	go func() {
+		io.Copy(a, b)
+		done <- true
+	}()
+	go func() {
+		io.Copy(b, a)
+		done <- true
+	}()
+	//the callers close both connections when either side is done, which unblocks the other copy
+	<-done
+}
+
+func (u *userspaceForwarder) closeAll() {
+	u.Lock()
+	defer u.Unlock()
+	for port, f := range u.forwards {
+		f.listener.Close()
+		delete(u.forwards, port)
+	}
+}
+
+//sshDialer opens connections from the node of the cluster, through a shared SSH connection
+type sshDialer struct {
+	sync.Mutex
+	driver drivers.Driver
+	client *ssh.Client
+}
+
+func (d *sshDialer) setDriver(driver drivers.Driver) {
+	d.Lock()
+	defer d.Unlock()
+	d.driver = driver
+}
+
+func (d *sshDialer) Dial(network, addr string) (net.Conn, error) {
+	for attempt := 0; ; attempt++ {
+		client, err := d.getClient()
+		if err != nil {
+			return nil, err
+		}
+		conn, err := client.Dial(network, addr)
+		if err == nil {
+			return conn, nil
+		}
+		if _, ok := err.(*ssh.OpenChannelError); ok || attempt > 0 {
+			return nil, err
+		}
+		//the SSH connection went stale, e.g. because the machine was restarted
+		glog.Infof("reconnecting to the cluster after error: %v", err)
+		d.dropClient(client)
+	}
+}
+
+//getClient returns the shared SSH connection, connecting to the cluster if there is none
+func (d *sshDialer) getClient() (*ssh.Client, error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.client == nil {
+		if d.driver == nil {
+			return nil, errors.New("the cluster is not running")
+		}
+		client, err := sshutil.NewSSHClient(d.driver)
+		if err != nil {
+			return nil, errors.Wrap(err, "ssh client")
+		}
+		d.client = client
+	}
+	return d.client, nil
+}
+
+//dropClient closes a stale SSH connection, unless another dial has already replaced it
+func (d *sshDialer) dropClient(client *ssh.Client) {
+	d.Lock()
+	defer d.Unlock()
+	client.Close()
+	if d.client == client {
+		d.client = nil
+	}
+}
+
+func (d *sshDialer) close() {
+	d.Lock()
+	defer d.Unlock()
+	if d.client != nil {
+		d.client.Close()
+		d.client = nil
+	}
+}
+
+//userspaceTunnel makes services of type LoadBalancer reachable on host listeners instead of through a route,
+//and sets the ingress of the services to the address of the listeners
+type userspaceTunnel struct {
+	//collaborators
+	clusterInspector     *clusterInspector
+	loadBalancerEmulator loadBalancerEmulator
+	forwarder            *userspaceForwarder
+	dialer               *sshDialer
+	coreV1Client         v1.CoreV1Interface
+	reporter             reporter
+
+	status *Status
+}
+
+func newUserspaceTunnel(machineName string,
+	machineAPI libmachine.API,
+	configLoader config.Loader,
+	v1Core v1.CoreV1Interface, bindAddress string) (*userspaceTunnel, error) {
+	ci := &clusterInspector{
+		machineName:  machineName,
+		machineAPI:   machineAPI,
+		configLoader: configLoader,
+	}
+	state, _, err := ci.getStateAndHost()
+	machineAPI.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine cluster info: %s", err)
+	}
+	if net.ParseIP(bindAddress) == nil {
+		return nil, fmt.Errorf("invalid bind address: %s", bindAddress)
+	}
+
+	d := &sshDialer{}
+	forwarder := newUserspaceForwarder(bindAddress, d)
+	lbEmulator := newLoadBalancerEmulator(v1Core)
+	ip := ingressAddress(bindAddress)
+	lbEmulator.ingressIP = func(svc core_v1.Service) string {
+		if !forwarder.isForwarded(svc) {
+			return ""
+		}
+		return ip
+	}
+
+	return &userspaceTunnel{
+		clusterInspector:     ci,
+		loadBalancerEmulator: lbEmulator,
+		forwarder:            forwarder,
+		dialer:               d,
+		coreV1Client:         v1Core,
+		reporter: &simpleReporter{
+			out: os.Stdout,
+		},
+		status: &Status{
+			TunnelID: ID{
+				MachineName: machineName,
+				Pid:         getPid(),
+			},
+			MinikubeState: state,
+		},
+	}, nil
+}
+
+//ingressAddress returns the address to reach listeners on the bind address at
+func ingressAddress(bindAddress string) string {
+	ip := net.ParseIP(bindAddress)
+	if ip.IsUnspecified() {
+		if ip.To4() == nil {
+			return net.IPv6loopback.String()
+		}
+		return "127.0.0.1"
+	}
+	return ip.String()
+}
+
+func (t *userspaceTunnel) cleanup() *Status {
+	glog.V(3).Info("closing userspace forwards")
+	t.forwarder.closeAll()
+	t.dialer.close()
+	if t.status.MinikubeState == Running {
+		t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.loadBalancerEmulator.Cleanup()
+	}
+	return t.status
+}
+
+func (t *userspaceTunnel) update() *Status {
+	glog.V(3).Info("updating tunnel status...")
+	state, h, err := t.clusterInspector.getStateAndHost()
+	t.status.MinikubeState, t.status.MinikubeError = state, err
+	defer t.clusterInspector.machineAPI.Close()
+	if t.status.MinikubeState == Running {
+		t.dialer.setDriver(h.Driver)
+		t.status.RouteError = t.syncForwards()
+		t.status.PatchedServices, t.status.LoadBalancerEmulatorError = t.loadBalancerEmulator.PatchServices()
+	}
+	glog.V(3).Infof("sending report %s", t.status)
+	t.reporter.Report(t.status.Clone())
+	return t.status
+}
+
+func (t *userspaceTunnel) syncForwards() error {
+	serviceList, err := t.coreV1Client.Services("").List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "listing services")
+	}
+	return t.forwarder.sync(serviceList.Items)
+}
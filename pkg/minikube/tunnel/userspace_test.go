@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	apiV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//echoDialer connects every address to a local echo server, and records the addresses
+type echoDialer struct {
+	sync.Mutex
+	addr    string
+	targets []string
+}
+
+func (d *echoDialer) Dial(network, addr string) (net.Conn, error) {
+	d.Lock()
+	d.targets = append(d.targets, addr)
+	d.Unlock()
+	return net.Dial(network, d.addr)
+}
+
+func newEchoDialer(t *testing.T) *echoDialer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				line, _ := bufio.NewReader(conn).ReadString('\n')
+				fmt.Fprintf(conn, "echo: %s", line)
+			}()
+		}
+	}()
+	return &echoDialer{addr: l.Addr().String()}
+}
+
+func freePort(t *testing.T) int32 {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer l.Close()
+	return int32(l.Addr().(*net.TCPAddr).Port)
+}
+
+func lbService(namespace, name, clusterIP string, ports ...int32) apiV1.Service {
+	svc := apiV1.Service{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: apiV1.ServiceSpec{
+			Type:      "LoadBalancer",
+			ClusterIP: clusterIP,
+		},
+	}
+	for _, p := range ports {
+		svc.Spec.Ports = append(svc.Spec.Ports, apiV1.ServicePort{Port: p, Protocol: apiV1.ProtocolTCP})
+	}
+	return svc
+}
+
+func TestUserspaceForwarder(t *testing.T) {
+	d := newEchoDialer(t)
+	f := newUserspaceForwarder("127.0.0.1", d)
+	defer f.closeAll()
+
+	port := freePort(t)
+	svc := lbService("default", "echo", "10.96.0.10", port)
+	if err := f.sync([]apiV1.Service{svc}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if !f.isForwarded(svc) {
+		t.Errorf("expected %s to be forwarded", svc.Name)
+	}
+
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	fmt.Fprintf(conn, "hello\n")
+	got, err := bufio.NewReader(conn).ReadString('\n')
+	conn.Close()
+	if err != nil || got != "echo: hello\n" {
+		t.Errorf("got %q, %v, expected %q", got, err, "echo: hello\n")
+	}
+	expected := []string{fmt.Sprintf("10.96.0.10:%d", port)}
+	d.Lock()
+	if !reflect.DeepEqual(d.targets, expected) {
+		t.Errorf("targets = %v, expected %v", d.targets, expected)
+	}
+	d.Unlock()
+
+	if err := f.sync(nil); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if f.isForwarded(svc) {
+		t.Errorf("expected %s to not be forwarded after removal", svc.Name)
+	}
+	if conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+		conn.Close()
+		t.Errorf("expected port %d to be closed", port)
+	}
+}
+
+func TestUserspaceForwarderConflicts(t *testing.T) {
+	f := newUserspaceForwarder("127.0.0.1", newEchoDialer(t))
+	defer f.closeAll()
+
+	port := freePort(t)
+	first := lbService("a", "first", "10.96.0.10", port)
+	second := lbService("b", "second", "10.96.0.11", port)
+	udp := lbService("c", "udp", "10.96.0.12", port)
+	udp.Spec.Ports[0].Protocol = apiV1.ProtocolUDP
+
+	err := f.sync([]apiV1.Service{first, second, udp})
+	if err == nil || !strings.Contains(err.Error(), "is already forwarded to a/first") {
+		t.Errorf("expected a conflict error, got: %v", err)
+	}
+	if !f.isForwarded(first) {
+		t.Errorf("expected %s to be forwarded", first.Name)
+	}
+	if f.isForwarded(second) {
+		t.Errorf("expected %s to not be forwarded", second.Name)
+	}
+}
+
+func TestIngressAddress(t *testing.T) {
+	tcs := map[string]string{
+		"127.0.0.1":    "127.0.0.1",
+		"0.0.0.0":      "127.0.0.1",
+		"::":           "::1",
+		"192.168.0.10": "192.168.0.10",
+	}
+	for bind, expected := range tcs {
+		if got := ingressAddress(bind); got != expected {
+			t.Errorf("ingressAddress(%s) = %s, expected %s", bind, got, expected)
+		}
+	}
+}
+
+func TestPatchServicesWithoutIngressIP(t *testing.T) {
+	reachable := lbService("ns1", "reachable", "10.96.0.3", 80)
+	unreachable := lbService("ns2", "unreachable", "10.96.0.4", 80)
+	unreachable.Status.LoadBalancer.Ingress = []apiV1.LoadBalancerIngress{{IP: "127.0.0.1"}}
+	client := newStubCoreClient(&apiV1.ServiceList{Items: []apiV1.Service{reachable, unreachable}})
+
+	requestSender := &countingRequestSender{}
+	patchConverter := &recordingPatchConverter{}
+	patcher := newLoadBalancerEmulator(client)
+	patcher.requestSender = requestSender
+	patcher.patchConverter = patchConverter
+	patcher.ingressIP = func(svc apiV1.Service) string {
+		if svc.Name == "reachable" {
+			return "127.0.0.1"
+		}
+		return ""
+	}
+
+	if _, err := patcher.PatchServices(); err != nil {
+		t.Fatalf("PatchServices: %v", err)
+	}
+	var bodies []string
+	for _, p := range patchConverter.patches {
+		bodies = append(bodies, p.ResourceName+" "+p.BodyContent)
+	}
+	expected := []string{
+		`reachable [{"op": "add", "path": "/status/loadBalancer/ingress", "value":  [ { "ip": "127.0.0.1" } ] }]`,
+		`unreachable [{"op": "remove", "path": "/status/loadBalancer/ingress" }]`,
+	}
+	if !reflect.DeepEqual(bodies, expected) {
+		t.Errorf("patches = %v, expected %v", bodies, expected)
+	}
+}
@@ -28,6 +28,7 @@ const (
 	DefaultMinikubeDirectory = "/var/lib/minikube"
 	DefaultCertPath          = DefaultMinikubeDirectory + "/certs/"
 	DefaultKubeConfigPath    = DefaultMinikubeDirectory + "/kubeconfig"
+	DefaultEtcdDataDir       = "/data/minikube"
 	DefaultDNSDomain         = "cluster.local"
 	DefaultServiceCIDR       = "10.96.0.0/12"
 )
@@ -291,6 +291,19 @@ func getIPFromKubeConfig(filename, machineName string) (net.IP, error) {
 	return ip, nil
 }
 
+// GetKubeConfigEndpoint returns the server URL stored for minikube in the kubeconfig specified
+func GetKubeConfigEndpoint(filename, machineName string) (string, error) {
+	con, err := ReadConfigOrNew(filename)
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting kubeconfig status")
+	}
+	cluster, ok := con.Clusters[machineName]
+	if !ok {
+		return "", errors.Errorf("Kubeconfig does not have a record of the machine cluster")
+	}
+	return cluster.Server, nil
+}
+
 // GetPortFromKubeConfig returns the Port number stored for minikube in the kubeconfig specified
 func GetPortFromKubeConfig(filename, machineName string) (int, error) {
 	con, err := ReadConfigOrNew(filename)
@@ -332,6 +332,42 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
+func TestGetKubeConfigEndpoint(t *testing.T) {
+
+	var tests = []struct {
+		description string
+		cfg         []byte
+		endpoint    string
+		err         bool
+	}{
+		{
+			description: "normal endpoint",
+			cfg:         fakeKubeCfg2,
+			endpoint:    "https://192.168.10.100:8443",
+		},
+		{
+			description: "no minikube cluster",
+			cfg:         fakeKubeCfg,
+			err:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			configFilename := tempFile(t, test.cfg)
+			endpoint, err := GetKubeConfigEndpoint(configFilename, "minikube")
+			if err != nil && !test.err {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+			if err == nil && test.err {
+				t.Errorf("Expected error but got none: %v", err)
+			}
+			if endpoint != test.endpoint {
+				t.Errorf("Endpoint returned: %s does not match endpoint given: %s", endpoint, test.endpoint)
+			}
+		})
+	}
+}
+
 func TestGetIPFromKubeConfig(t *testing.T) {
 
 	var tests = []struct {